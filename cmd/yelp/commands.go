@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// businessHeaders are the columns businesses are printed with.
+var businessHeaders = []string{"ID", "NAME", "RATING", "REVIEWS", "PRICE", "PHONE", "ADDRESS", "CITY", "DISTANCE"}
+
+// businessRow formats a business as a row of businessHeaders.
+func businessRow(b yelp.Business) []string {
+	distance := ""
+	if b.Distance > 0 {
+		distance = strconv.FormatFloat(float64(b.Distance), 'f', 0, 32)
+	}
+
+	return []string{
+		b.ID,
+		b.Name,
+		strconv.FormatFloat(float64(b.Rating), 'f', -1, 32),
+		strconv.Itoa(b.ReviewCount),
+		b.Price,
+		b.Phone,
+		b.Location.Address1,
+		b.Location.City,
+		distance,
+	}
+}
+
+// businessesResult builds the result of a command returning a list of businesses.
+func businessesResult(value interface{}, businesses []yelp.Business) *result {
+	res := &result{value: value, headers: businessHeaders}
+	for _, b := range businesses {
+		res.rows = append(res.rows, businessRow(b))
+	}
+
+	return res
+}
+
+// splitList splits a comma delimited flag value, ignoring empty items.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// parseTime parses an RFC 3339 flag value, returning the zero time when empty.
+func parseTime(name string, v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid -%s %q, expected RFC 3339 time like 2024-05-01T19:30:00-07:00", name, v)
+	}
+
+	return t, nil
+}
+
+// coordinateFlags registers the -latitude and -longitude flags.
+func coordinateFlags(fs *flag.FlagSet) (*float64, *float64) {
+	return fs.Float64("latitude", 0, "Latitude of the location to search nearby"), fs.Float64("longitude", 0, "Longitude of the location to search nearby")
+}
+
+// runSearch runs the search command, mapping its flags onto BusinessSearchReq.
+func runSearch(c *cli, fs *flag.FlagSet, args []string) (*result, error) {
+	var req yelp.BusinessSearchReq
+
+	fs.StringVar(&req.Term, "term", "", "Search term, for example \"food\" or a business name")
+	fs.StringVar(&req.Location, "location", "", "Geographic area to search in, for example \"San Francisco, CA\"")
+	latitude, longitude := coordinateFlags(fs)
+	fs.IntVar(&req.Radius, "radius", 0, "Search radius in meters, up to 40000")
+	categories := fs.String("categories", "", "Comma delimited category aliases, for example bars,french")
+	fs.StringVar(&req.Locale, "locale", "", "Locale of the results, for example en_CA")
+	fs.IntVar(&req.Limit, "limit", 0, "Number of results, up to 50")
+	fs.IntVar(&req.Offset, "offset", 0, "Offset of the first result")
+	sortBy := fs.String("sort-by", "", "Sort mode: best_match, rating, review_count or distance")
+	price := fs.String("price", "", "Comma delimited pricing levels from 1 to 4, for example 1,2 or $,$$")
+	fs.BoolVar(&req.OpenNow, "open-now", false, "Only return businesses open now")
+	openAt := fs.String("open-at", "", "Only return businesses open at this RFC 3339 time")
+	attributes := fs.String("attributes", "", "Comma delimited attributes, for example hot_and_new,deals")
+	fs.StringVar(&req.DevicePlatform, "device-platform", "", "Platform of the returned URLs: android, ios or mobile-generic")
+	reservationAt := fs.String("reservation-at", "", "RFC 3339 time of the reservation to search availability for")
+	fs.IntVar(&req.ReservationCovers, "reservation-covers", 0, "Number of people attending the reservation, from 1 to 10")
+	fs.BoolVar(&req.MatchesPartySizeParam, "matches-party-size", false, "Only return businesses with availability for the reservation covers")
+	all := fs.Bool("all", false, "Fetch every page of results, up to -max-results")
+	maxResults := fs.Int("max-results", 0, "Maximum number of results fetched with -all. Defaults to every result")
+
+	if _, err := c.parse(fs, args, 0); err != nil {
+		return nil, err
+	}
+
+	var err error
+	req.Latitude, req.Longitude = float32(*latitude), float32(*longitude)
+	req.Categories = yelp.Categories(splitList(*categories))
+	req.SortBy = yelp.SortBy(*sortBy)
+
+	for _, p := range splitList(*price) {
+		level, convErr := yelp.ParsePriceLevel(p)
+		if n, atoiErr := strconv.Atoi(p); atoiErr == nil {
+			level, convErr = yelp.PriceLevel(n), nil
+		}
+		if convErr != nil {
+			return nil, fmt.Errorf("invalid -price %q, expected levels from 1 to 4 or $ to $$$$", p)
+		}
+		req.Price = append(req.Price, level)
+	}
+
+	for _, a := range splitList(*attributes) {
+		req.Attributes = append(req.Attributes, yelp.Attribute(a))
+	}
+
+	if req.OpenAt, err = parseTime("open-at", *openAt); err != nil {
+		return nil, err
+	}
+	if req.ReservationAt, err = parseTime("reservation-at", *reservationAt); err != nil {
+		return nil, err
+	}
+
+	if !*all {
+		res, err := c.client.BusinessSearch(req)
+		if err != nil {
+			return nil, err
+		}
+		return businessesResult(res, res.Businesses), nil
+	}
+
+	it := c.client.NewBusinessSearchIterator(context.Background(), req, &yelp.BusinessSearchIteratorOptions{MaxResults: *maxResults, SkipDuplicates: true})
+
+	businesses := []yelp.Business{}
+	for it.Next() {
+		businesses = append(businesses, it.Business())
+	}
+	if err = it.Err(); err != nil {
+		return nil, err
+	}
+
+	return businessesResult(businesses, businesses), nil
+}
+
+// runDetails runs the details command.
+func runDetails(c *cli, fs *flag.FlagSet, args []string) (*result, error) {
+	locale := fs.String("locale", "", "Locale of the business information, for example en_CA")
+
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.BusinessDetails(args[0], *locale)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]string, len(res.Categories))
+	for i, category := range res.Categories {
+		categories[i] = category.Alias
+	}
+
+	return &result{
+		value:   res,
+		headers: []string{"ID", "NAME", "RATING", "REVIEWS", "PRICE", "PHONE", "ADDRESS", "CATEGORIES", "CLAIMED", "CLOSED", "URL"},
+		rows: [][]string{{
+			res.ID,
+			res.Name,
+			strconv.FormatFloat(float64(res.Rating), 'f', -1, 32),
+			strconv.Itoa(res.ReviewCount),
+			res.Price,
+			res.Phone,
+			strings.Join(res.Location.DisplayAddress, ", "),
+			strings.Join(categories, ","),
+			strconv.FormatBool(res.IsClaimed),
+			strconv.FormatBool(res.IsClosed),
+			res.URL,
+		}},
+	}, nil
+}
+
+// runPhone runs the phone command.
+func runPhone(c *cli, fs *flag.FlagSet, args []string) (*result, error) {
+	locale := fs.String("locale", "", "Locale of the business information, for example en_CA")
+
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.BusinessPhoneSearch(args[0], *locale)
+	if err != nil {
+		return nil, err
+	}
+
+	return businessesResult(res, res.Businesses), nil
+}
+
+// runReviews runs the reviews command.
+func runReviews(c *cli, fs *flag.FlagSet, args []string) (*result, error) {
+	locale := fs.String("locale", "", "Locale of the reviews, for example en_CA")
+
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.BusinessReviews(args[0], *locale)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &result{value: res, headers: []string{"ID", "RATING", "USER", "CREATED", "TEXT"}}
+	for _, r := range res.Reviews {
+		out.rows = append(out.rows, []string{r.ID, strconv.Itoa(r.Rating), r.User.Name, r.TimeCreated, r.Text})
+	}
+
+	return out, nil
+}
+
+// runTransactions runs the transactions command.
+func runTransactions(c *cli, fs *flag.FlagSet, args []string) (*result, error) {
+	var req yelp.BusinessTransactionReq
+
+	fs.StringVar(&req.Location, "location", "", "Address of the location to deliver to")
+	latitude, longitude := coordinateFlags(fs)
+
+	if _, err := c.parse(fs, args, 0); err != nil {
+		return nil, err
+	}
+
+	req.Latitude, req.Longitude = float32(*latitude), float32(*longitude)
+
+	res, err := c.client.TransactionSearch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return businessesResult(res, res.Businesses), nil
+}
+
+// runAutocomplete runs the autocomplete command.
+func runAutocomplete(c *cli, fs *flag.FlagSet, args []string) (*result, error) {
+	var req yelp.BusinessAutocompleteReq
+
+	latitude, longitude := coordinateFlags(fs)
+	fs.StringVar(&req.Locale, "locale", "", "Locale of the suggestions, for example en_CA")
+
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Text = args[0]
+	req.Coordinates = yelp.Coordinates{Latitude: float32(*latitude), Longitude: float32(*longitude)}
+
+	res, err := c.client.Autocomplete(req)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &result{value: res, headers: []string{"TYPE", "ID", "TEXT"}}
+	for _, t := range res.Terms {
+		out.rows = append(out.rows, []string{"term", "", t.Text})
+	}
+	for _, b := range res.Businesses {
+		out.rows = append(out.rows, []string{"business", b.ID, b.Name})
+	}
+	for _, category := range res.Categories {
+		out.rows = append(out.rows, []string{"category", category.Alias, category.Title})
+	}
+
+	return out, nil
+}
+
+// runMatch runs the match command, mapping its flags onto BusinessMatchReq.
+func runMatch(c *cli, fs *flag.FlagSet, args []string) (*result, error) {
+	var req yelp.BusinessMatchReq
+
+	fs.StringVar(&req.Name, "name", "", "Name of the business (required)")
+	fs.StringVar(&req.Address1, "address1", "", "First line of the address of the business (required)")
+	fs.StringVar(&req.Address2, "address2", "", "Second line of the address of the business")
+	fs.StringVar(&req.Address3, "address3", "", "Third line of the address of the business")
+	fs.StringVar(&req.City, "city", "", "City of the business (required)")
+	fs.StringVar(&req.State, "state", "", "ISO 3166-2 state code of the business (required)")
+	fs.StringVar(&req.Country, "country", "", "ISO 3166-1 alpha-2 country code of the business (required)")
+	fs.StringVar(&req.PostalCode, "postal-code", "", "Postal code of the business")
+	latitude, longitude := coordinateFlags(fs)
+	fs.StringVar(&req.Phone, "phone", "", "Phone number of the business")
+	fs.StringVar(&req.YelpBusinessID, "yelp-business-id", "", "Yelp ID of the business, used as a hint")
+	fs.IntVar(&req.Limit, "limit", 0, "Maximum number of businesses, from 1 to 10")
+	fs.StringVar(&req.MatchThreshold, "match-threshold", "", "Match quality threshold: none, default or strict")
+
+	if _, err := c.parse(fs, args, 0); err != nil {
+		return nil, err
+	}
+
+	req.Latitude, req.Longitude = float32(*latitude), float32(*longitude)
+
+	res, err := c.client.BusinessMatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &result{value: res, headers: []string{"ID", "NAME", "PHONE", "ADDRESS"}}
+	for _, b := range res.Businesses {
+		out.rows = append(out.rows, []string{b.ID, b.Name, b.Phone, strings.Join(b.Location.DisplayAddress, ", ")})
+	}
+
+	return out, nil
+}
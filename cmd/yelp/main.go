@@ -0,0 +1,194 @@
+// Command yelp queries the Yelp Fusion API from the command line.
+//
+// Usage:
+//
+//	yelp <command> [flags] [arguments]
+//
+// Commands are search, details, phone, reviews, transactions, autocomplete and match. Run "yelp <command> -h" for their flags.
+//
+// The API key is read from the YELP_API_KEY environment variable, or else from the "api_key" field of the JSON config file
+// given with -config, which defaults to yelp/config.json in the user config directory, for example ~/.config/yelp/config.json.
+// Results are printed as a table, JSON or CSV depending on -format.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// config is the content of the config file.
+type config struct {
+	APIKey  string `json:"api_key"`  // API key used when YELP_API_KEY isn't set
+	BaseURI string `json:"base_uri"` // Optional. Base URI of the API, for example to use a proxy. Defaults to yelp.BASE_URI
+}
+
+// env provides the environment variables, so tests can run commands without touching the process environment.
+type env func(key string) string
+
+// command is a subcommand of the CLI.
+type command struct {
+	usage string                                                         // Arguments of the command, shown in its help
+	help  string                                                         // One line description of the command
+	run   func(c *cli, fs *flag.FlagSet, args []string) (*result, error) // Parses the command flags and queries the API
+}
+
+// commands lists the subcommands by name.
+var commands = map[string]command{
+	"search":       {usage: "[flags]", help: "Search businesses", run: runSearch},
+	"details":      {usage: "[flags] <business id>", help: "Show the details of a business", run: runDetails},
+	"phone":        {usage: "[flags] <phone number>", help: "Search businesses by phone number", run: runPhone},
+	"reviews":      {usage: "[flags] <business id>", help: "Show the reviews of a business", run: runReviews},
+	"transactions": {usage: "[flags]", help: "Search businesses delivering to a location", run: runTransactions},
+	"autocomplete": {usage: "[flags] <text>", help: "Suggest terms, businesses and categories", run: runAutocomplete},
+	"match":        {usage: "[flags]", help: "Match a business from its name and address", run: runMatch},
+}
+
+// cli holds the state shared by the commands of a single run.
+type cli struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv env
+
+	format     string
+	configPath string
+	client     *yelp.Client
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+// run executes the command line and returns the exit code.
+func run(args []string, stdout io.Writer, stderr io.Writer, getenv env) int {
+	c := &cli{stdout: stdout, stderr: stderr, getenv: getenv}
+
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		c.usage()
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "yelp: unknown command %q\n\n", args[0])
+		c.usage()
+		return 2
+	}
+
+	fs := flag.NewFlagSet("yelp "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&c.format, "format", FORMAT_TABLE, "Output format: table, json or csv")
+	fs.StringVar(&c.configPath, "config", "", "Path of the JSON config file holding the API key. Defaults to yelp/config.json in the user config directory")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: yelp %s %s\n\n%s.\n\nFlags:\n", args[0], cmd.usage, cmd.help)
+		fs.PrintDefaults()
+	}
+
+	res, err := cmd.run(c, fs, args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "yelp: %v\n", err)
+		return 1
+	}
+
+	if err = res.write(stdout, c.format); err != nil {
+		fmt.Fprintf(stderr, "yelp: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// usage prints the list of commands.
+func (c *cli) usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprint(c.stderr, "Usage: yelp <command> [flags] [arguments]\n\nCommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(c.stderr, "  %-14s %s\n", name, commands[name].help)
+	}
+	fmt.Fprint(c.stderr, "\nThe API key is read from YELP_API_KEY or the api_key field of the config file.\n")
+}
+
+// parse parses the command flags, checks the output format and the number of positional arguments, and creates the client.
+func (c *cli) parse(fs *flag.FlagSet, args []string, positional int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() != positional {
+		fs.Usage()
+		return nil, fmt.Errorf("expected %d argument(s), got %d", positional, fs.NArg())
+	}
+
+	switch c.format {
+	case FORMAT_TABLE, FORMAT_JSON, FORMAT_CSV:
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected table, json or csv", c.format)
+	}
+
+	cfg, err := c.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := c.getenv("YELP_API_KEY")
+	if apiKey == "" {
+		apiKey = cfg.APIKey
+	}
+	if apiKey == "" {
+		return nil, errors.New("no API key, set YELP_API_KEY or api_key in the config file")
+	}
+
+	if c.client, err = yelp.Init(&yelp.ClientOptions{APIKey: apiKey, RetryPolicy: yelp.DefaultRetryPolicy()}); err != nil {
+		return nil, err
+	}
+
+	if cfg.BaseURI != "" {
+		c.client.BaseURI = strings.TrimSuffix(cfg.BaseURI, "/")
+	}
+
+	return fs.Args(), nil
+}
+
+// loadConfig reads the config file. A missing default config file is not an error.
+func (c *cli) loadConfig() (config, error) {
+	var cfg config
+
+	path := c.configPath
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return cfg, nil
+		}
+		path = filepath.Join(dir, "yelp", "config.json")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && c.configPath == "" {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+
+	return cfg, nil
+}
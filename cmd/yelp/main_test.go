@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/yelptest"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupServer(t *testing.T) (*yelptest.Server, string) {
+	server := yelptest.NewServer()
+	t.Cleanup(server.Close)
+
+	server.AddBusinesses(
+		yelp.BusinessDetailsRes{
+			ID: "gary-danko", Name: "Gary Danko", Rating: 4.5, ReviewCount: 4525, Price: "$$$$", Phone: "+14157492060",
+			Location: yelp.LocationBusinessDetails{
+				Location:       yelp.Location{Address1: "800 N Point St", City: "San Francisco", State: "CA", ZipCode: "94109", Country: "US"},
+				DisplayAddress: []string{"800 N Point St", "San Francisco, CA 94109"},
+			},
+		},
+		yelp.BusinessDetailsRes{
+			ID: "tartine", Name: "Tartine Bakery", Rating: 4, ReviewCount: 8000, Price: "$$", Phone: "+14154872600",
+			Location: yelp.LocationBusinessDetails{Location: yelp.Location{City: "San Francisco", State: "CA", Country: "US"}},
+		},
+	)
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	config := fmt.Sprintf(`{"api_key": %q, "base_uri": %q}`, yelptest.API_KEY, server.URL)
+	assert.NoError(t, ioutil.WriteFile(configPath, []byte(config), 0o600))
+
+	return server, configPath
+}
+
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr, func(string) string { return "" })
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestSearchTable(t *testing.T) {
+	// Arrange
+	_, configPath := setupServer(t)
+
+	// Act
+	code, stdout, stderr := runCLI("search", "-config", configPath, "-location", "San Francisco", "-price", "$$,3", "-sort-by", "rating")
+
+	// Assert
+	assert.Equal(t, 0, code, stderr)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, []string{"ID", "NAME", "RATING", "REVIEWS", "PRICE", "PHONE", "ADDRESS", "CITY", "DISTANCE"}, strings.Fields(lines[0]))
+	assert.True(t, strings.HasPrefix(lines[1], "tartine"))
+	assert.Contains(t, lines[1], "Tartine Bakery")
+}
+
+func TestDetailsJSON(t *testing.T) {
+	// Arrange
+	_, configPath := setupServer(t)
+
+	// Act
+	code, stdout, stderr := runCLI("details", "-config", configPath, "-format", "json", "gary-danko")
+
+	// Assert
+	assert.Equal(t, 0, code, stderr)
+
+	var res yelp.BusinessDetailsRes
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &res))
+	assert.Equal(t, "Gary Danko", res.Name)
+}
+
+func TestMatchCSV(t *testing.T) {
+	// Arrange
+	_, configPath := setupServer(t)
+
+	// Act
+	code, stdout, stderr := runCLI("match", "-config", configPath, "-format", "csv",
+		"-name", "Gary Danko", "-address1", "800 N Point St", "-city", "San Francisco", "-state", "CA", "-country", "US", "-postal-code", "94109")
+
+	// Assert
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "ID,NAME,PHONE,ADDRESS\ngary-danko,Gary Danko,+14157492060,\"800 N Point St, San Francisco, CA 94109\"\n", stdout)
+}
+
+func TestAPIKeyFromEnvironment(t *testing.T) {
+	// Arrange
+	server, _ := setupServer(t)
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, ioutil.WriteFile(configPath, []byte(fmt.Sprintf(`{"api_key": "stale-key", "base_uri": %q}`, server.URL)), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	getenv := func(key string) string {
+		if key == "YELP_API_KEY" {
+			return yelptest.API_KEY
+		}
+		return ""
+	}
+
+	// Act
+	code := run([]string{"phone", "-config", configPath, "+14154872600"}, &stdout, &stderr, getenv)
+
+	// Assert
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stdout.String(), "tartine")
+}
+
+func TestErrors(t *testing.T) {
+	// Arrange
+	_, configPath := setupServer(t)
+	missingConfig := filepath.Join(t.TempDir(), "missing.json")
+
+	// Act
+	unknownCode, _, unknownErr := runCLI("delete")
+	noKeyCode, _, noKeyErr := runCLI("details", "-config", missingConfig, "gary-danko")
+	notFoundCode, _, notFoundErr := runCLI("details", "-config", configPath, "missing")
+	formatCode, _, formatErr := runCLI("details", "-config", configPath, "-format", "xml", "gary-danko")
+
+	// Assert
+	assert.Equal(t, 2, unknownCode)
+	assert.Contains(t, unknownErr, `unknown command "delete"`)
+
+	assert.Equal(t, 1, noKeyCode)
+	assert.Contains(t, noKeyErr, "no such file")
+
+	assert.Equal(t, 1, notFoundCode)
+	assert.Contains(t, notFoundErr, "BUSINESS_NOT_FOUND")
+
+	assert.Equal(t, 1, formatCode)
+	assert.Contains(t, formatErr, `unknown format "xml"`)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats accepted by -format
+const (
+	FORMAT_TABLE = "table"
+	FORMAT_JSON  = "json"
+	FORMAT_CSV   = "csv"
+)
+
+// result is the output of a command: the raw response, written as JSON, and its rows, written as a table or CSV.
+type result struct {
+	value   interface{}
+	headers []string
+	rows    [][]string
+}
+
+// write prints the result in the format.
+func (r *result) write(w io.Writer, format string) error {
+	switch format {
+	case FORMAT_JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r.value)
+	case FORMAT_CSV:
+		cw := csv.NewWriter(w)
+		cw.Write(r.headers)
+		cw.WriteAll(r.rows)
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(r.headers, "\t"))
+
+	for _, row := range r.rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(cell)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"os"
+)
+
+func main() {
+	// Create client using access token from environment variables
+	client, err := yelp.Init(&yelp.ClientOptions{APIKey: os.Getenv("YELP_API_KEY")})
+
+	if err != nil {
+		fmt.Printf("Oh noes, error: %v\n", err)
+		return
+	}
+
+	params := yelp.BusinessMatchReq{
+		Name:     "Gary Danko",
+		Address1: "800 N Point St",
+		City:     "San Francisco",
+		State:    "CA",
+		Country:  "US",
+	}
+
+	res, err := client.BusinessMatch(params)
+	if err != nil {
+		fmt.Printf("Oh noes, error: %v", err)
+		return
+	}
+
+	for _, business := range res.Businesses {
+		fmt.Printf("ID: %v\n", business.ID)
+		fmt.Printf("Name: %v\n", business.Name)
+		fmt.Printf("Address: %v\n", business.Location.DisplayAddress)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"os"
+)
+
+func main() {
+	// Create client using access token from environment variables
+	client, err := yelp.Init(&yelp.ClientOptions{APIKey: os.Getenv("YELP_API_KEY")})
+
+	if err != nil {
+		fmt.Printf("Oh noes, error: %v\n", err)
+		return
+	}
+
+	params := yelp.EventSearchReq{
+		Location: "220 Yonge St, Toronto, ON",
+		Limit:    10,
+		SortOn:   yelp.EVENT_SORT_ON_TIME_START,
+	}
+
+	res, err := client.EventSearch(params)
+	if err != nil {
+		fmt.Printf("Oh noes, error: %v", err)
+		return
+	}
+
+	fmt.Printf("Total events: %v\n", res.Total)
+
+	for _, event := range res.Events {
+		fmt.Printf("ID: %v\n", event.ID)
+		fmt.Printf("Name: %v\n", event.Name)
+		fmt.Printf("Starts: %v\n", event.TimeStart)
+	}
+}
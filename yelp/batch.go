@@ -0,0 +1,87 @@
+package yelp
+
+import (
+	"context"
+	"sync"
+)
+
+// BUSINESS_DETAILS_BATCH_DEFAULT_CONCURRENCY is the number of workers used by BusinessDetailsBatch when none is configured
+const BUSINESS_DETAILS_BATCH_DEFAULT_CONCURRENCY = 4
+
+// BusinessDetailsBatchOptions configures how BusinessDetailsBatch fans out requests.
+type BusinessDetailsBatchOptions struct {
+	Concurrency int  // Optional. Number of requests in flight at once. Defaults to 4
+	StopOnError bool // Optional. Stop sending requests after the first failure. IDs that weren't fetched get the error of the context cancellation
+}
+
+// BusinessDetailsResult is the outcome of fetching the details of a single business in a batch.
+type BusinessDetailsResult struct {
+	ID       string             // ID of the business, as given to BusinessDetailsBatch
+	Business BusinessDetailsRes // Details of the business, empty when Err is set
+	Err      error              // Error returned for this ID, if any
+}
+
+// BusinessDetailsBatch fetches the details of every business in ids, running up to opts.Concurrency requests at a time.
+// Every request goes through the client's rate limiter, retries and cache like a single BusinessDetails call.
+//
+// Results are returned in the same order as ids, with a failing ID reported in its own Err without failing the others.
+// The returned error is only set when ctx is done before every ID was sent, so some IDs were skipped. Options may be nil.
+func (c *Client) BusinessDetailsBatch(ctx context.Context, ids []string, locale string, opts *BusinessDetailsBatchOptions) ([]BusinessDetailsResult, error) {
+	var options BusinessDetailsBatchOptions
+	if opts != nil {
+		options = *opts
+	}
+
+	workers := options.Concurrency
+	if workers <= 0 {
+		workers = BUSINESS_DETAILS_BATCH_DEFAULT_CONCURRENCY
+	}
+	if workers > len(ids) {
+		workers = len(ids)
+	}
+
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]BusinessDetailsResult, len(ids))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				res, err := c.BusinessDetailsWithContext(batchCtx, ids[i], locale)
+				results[i] = BusinessDetailsResult{ID: ids[i], Business: res, Err: err}
+
+				if err != nil && options.StopOnError {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	next := 0
+feed:
+	for ; next < len(ids) && batchCtx.Err() == nil; next++ {
+		select {
+		case indexes <- next:
+		case <-batchCtx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if next == len(ids) {
+		return results, nil
+	}
+
+	for i := next; i < len(ids); i++ {
+		results[i] = BusinessDetailsResult{ID: ids[i], Err: batchCtx.Err()}
+	}
+
+	return results, ctx.Err()
+}
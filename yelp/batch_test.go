@@ -0,0 +1,148 @@
+package yelp_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setupBatchServer(inFlight *int32, maxInFlight *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(inFlight, 1)
+		defer atomic.AddInt32(inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(maxInFlight, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/businesses/")
+		if strings.HasPrefix(id, "missing") {
+			w.WriteHeader(404)
+			fmt.Fprint(w, `{"error": {"code": "BUSINESS_NOT_FOUND", "description": "The requested business could not be found."}}`)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprintf(w, `{"id": %q, "name": "Business %s"}`, id, id)
+	}))
+}
+
+func TestBusinessDetailsBatch(t *testing.T) {
+	// Arrange
+	var inFlight, maxInFlight int32
+	ts := setupBatchServer(&inFlight, &maxInFlight)
+	defer ts.Close()
+
+	client := setup()
+	client.BaseURI = ts.URL
+
+	ids := make([]string, 20)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id-%d", i)
+	}
+	ids[7] = "missing-7"
+
+	// Act
+	results, err := client.BusinessDetailsBatch(context.Background(), ids, "", &yelp.BusinessDetailsBatchOptions{Concurrency: 3})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, results, 20)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(3))
+
+	for i, result := range results {
+		assert.Equal(t, ids[i], result.ID)
+
+		if i == 7 {
+			assert.ErrorIs(t, result.Err, yelp.ErrBusinessNotFound)
+			assert.Empty(t, result.Business.ID)
+			continue
+		}
+
+		assert.NoError(t, result.Err)
+		assert.Equal(t, ids[i], result.Business.ID)
+	}
+}
+
+func TestBusinessDetailsBatchStopOnError(t *testing.T) {
+	// Arrange
+	var inFlight, maxInFlight int32
+	ts := setupBatchServer(&inFlight, &maxInFlight)
+	defer ts.Close()
+
+	client := setup()
+	client.BaseURI = ts.URL
+
+	ids := []string{"missing-0", "id-1", "id-2", "id-3", "id-4", "id-5"}
+
+	// Act
+	results, err := client.BusinessDetailsBatch(context.Background(), ids, "", &yelp.BusinessDetailsBatchOptions{Concurrency: 1, StopOnError: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, yelp.ErrBusinessNotFound)
+	for _, result := range results[1:] {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
+
+func TestBusinessDetailsBatchContextCancelled(t *testing.T) {
+	// Arrange
+	var inFlight, maxInFlight int32
+	ts := setupBatchServer(&inFlight, &maxInFlight)
+	defer ts.Close()
+
+	client := setup()
+	client.BaseURI = ts.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	results, err := client.BusinessDetailsBatch(ctx, []string{"id-1", "id-2"}, "", nil)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, results, 2)
+	for _, result := range results {
+		assert.Error(t, result.Err)
+	}
+}
+
+func TestBusinessDetailsBatchContextCancelledAfterLastID(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, `{"id": "id-1", "name": "Business id-1"}`)
+	}))
+	defer ts.Close()
+
+	client := setup()
+	client.BaseURI = ts.URL
+
+	// Act
+	results, err := client.BusinessDetailsBatch(ctx, []string{"id-1"}, "", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "id-1", results[0].ID)
+}
@@ -1,21 +1,27 @@
 package yelp
 
+import "time"
+
 // BusinessSearchReq is the request payload for Business search API
 type BusinessSearchReq struct {
-	Term       string  `json:"term,omitempty"`       // Optional. Search term, for example "food" or "restaurants". The term may also be business names, such as "Starbucks". If term is not included the endpoint will default to searching across businesses from a small number of popular categories
-	Location   string  `json:"location,omitempty"`   // Required if either latitude or longitude is not provided. This string indicates the geographic area to be used when searching for businesses
-	Latitude   float32 `json:"latitude,omitempty"`   // Required if location is not provided. Latitude of the location you want to search nearby
-	Longitude  float32 `json:"longitude,omitempty"`  // Required if location is not provided. Longitude of the location you want to search nearby
-	Radius     int     `json:"radius,omitempty"`     // Optional. A suggested search radius in meters. This field is used as a suggestion to the search
-	Categories string  `json:"categories,omitempty"` // Optional. Categories to filter the search results with
-	Locale     string  `json:"locale,omitempty"`     // Optional. Specify the locale into which to localize the business information. See the list of supported locales. https://www.yelp.ca/developers/documentation/v3/supported_locales. Defaults to en_US
-	Limit      int     `json:"limit,omitempty"`      // Optional. Number of business results to return. By default, it will return 20. Maximum is 50
-	Offset     int     `json:"offset,omitempty"`     // Optional. Offset the list of returned business results by this amount
-	SortBy     string  `json:"sort_by,omitempty"`    // Optional. Suggestion to the search algorithm that the results be sorted by one of the these modes: best_match, rating, review_count or distance. The default is best_match
-	Price      string  `json:"price,omitempty"`      // Optional. Pricing levels to filter the search result with: 1 = $, 2 = $$, 3 = $$$, 4 = $$$$. The price filter can be a list of comma delimited pricing levels. For example, "1, 2, 3" will filter the results to show the ones that are $, $$, or $$$
-	OpenNow    bool    `json:"open_now,omitempty"`   // Optional. Default to false. When set to true, only return the businesses open now
-	OpenAt     int     `json:"open_at,omitempty"`    // Optional. An integer represending the Unix time in the same timezone of the search location
-	Attributes string  `json:"attributes,omitempty"` // Optional. See list of attributes to try out here. https://www.yelp.ca/developers/documentation/v3/business_search
+	Term                  string      `json:"term,omitempty"`                     // Optional. Search term, for example "food" or "restaurants". The term may also be business names, such as "Starbucks". If term is not included the endpoint will default to searching across businesses from a small number of popular categories
+	Location              string      `json:"location,omitempty"`                 // Required if either latitude or longitude is not provided. This string indicates the geographic area to be used when searching for businesses
+	Latitude              float32     `json:"latitude,omitempty"`                 // Required if location is not provided. Latitude of the location you want to search nearby
+	Longitude             float32     `json:"longitude,omitempty"`                // Required if location is not provided. Longitude of the location you want to search nearby
+	Radius                int         `json:"radius,omitempty"`                   // Optional. A suggested search radius in meters. This field is used as a suggestion to the search
+	Categories            Categories  `json:"categories,omitempty"`               // Optional. Category aliases to filter the search results with, for example {"bars", "french"}
+	Locale                string      `json:"locale,omitempty"`                   // Optional. Specify the locale into which to localize the business information. See the list of supported locales. https://www.yelp.ca/developers/documentation/v3/supported_locales. Defaults to en_US
+	Limit                 int         `json:"limit,omitempty"`                    // Optional. Number of business results to return. By default, it will return 20. Maximum is 50
+	Offset                int         `json:"offset,omitempty"`                   // Optional. Offset the list of returned business results by this amount
+	SortBy                SortBy      `json:"sort_by,omitempty"`                  // Optional. Suggestion to the search algorithm that the results be sorted by one of the these modes: SORT_BY_BEST_MATCH, SORT_BY_RATING, SORT_BY_REVIEW_COUNT or SORT_BY_DISTANCE. The default is best_match
+	Price                 PriceLevels `json:"price,omitempty"`                    // Optional. Pricing levels to filter the search result with: PRICE_LEVEL_1 = $, PRICE_LEVEL_2 = $$, PRICE_LEVEL_3 = $$$, PRICE_LEVEL_4 = $$$$. For example, {PRICE_LEVEL_1, PRICE_LEVEL_2} will filter the results to show the ones that are $ or $$
+	OpenNow               bool        `json:"open_now,omitempty"`                 // Optional. Default to false. When set to true, only return the businesses open now
+	OpenAt                time.Time   `json:"-"`                                  // Optional. Only return the businesses open at this time. Sent to Yelp as Unix time. Cannot be used together with OpenNow
+	Attributes            Attributes  `json:"attributes,omitempty"`               // Optional. Attributes to filter the search results with, for example {ATTRIBUTE_HOT_AND_NEW, ATTRIBUTE_DEALS}. See list of attributes to try out here. https://www.yelp.ca/developers/documentation/v3/business_search
+	DevicePlatform        string      `json:"device_platform,omitempty"`          // Optional. Platform used to decide which URLs to return: DEVICE_PLATFORM_ANDROID, DEVICE_PLATFORM_IOS or DEVICE_PLATFORM_MOBILE_GENERIC
+	ReservationAt         time.Time   `json:"-"`                                  // Optional. Date and time of the reservation to search availability for, in the time zone of the search location. Sent to Yelp as reservation_date (YYYY-MM-DD) and reservation_time (HH:MM). Requires ReservationCovers
+	ReservationCovers     int         `json:"reservation_covers,omitempty"`       // Optional. Number of people attending the reservation, between 1 and 10. Requires ReservationAt
+	MatchesPartySizeParam bool        `json:"matches_party_size_param,omitempty"` // Optional. When set to true, only return the businesses whose reservation availability matches ReservationCovers
 }
 
 // BusinessSearchRes is the response payload for Business Search API
@@ -23,6 +29,7 @@ type BusinessSearchRes struct {
 	Region     Region     `json:"region"`     // Suggested area in a map to display results in.
 	Total      int        `json:"total"`      // Total number of business results
 	Businesses []Business `json:"businesses"` // The list of business entries (see Business)
+	ResponseMeta
 }
 
 // BusinessDetailsRes is the response payload for Business Details API
@@ -47,12 +54,14 @@ type BusinessDetailsRes struct {
 	Transactions []string                `json:"transactions"`  // A list of Yelp transactions that the business is registered for. Current supported values are "pickup", "delivery", and "restaurant_reservation"
 	SpecialHours []SpecialHours          `json:"special_hours"` // Out of the ordinary hours for the business that apply on certain dates. Whenever these are set, they will override the regular business hours found in the 'hours' field
 	Messaging    Messaging               `json:"messaging"`     // Contains Business Messaging / Request a Quote information for this business. This field only appears in the response for businesses that have messaging enabled
+	ResponseMeta
 }
 
 // BusinessPhoneSearchRes is the response payload for Business Phone Search API
 type BusinessPhoneSearchRes struct {
 	Total      int        `json:"total"`
 	Businesses []Business `json:"businesses"`
+	ResponseMeta
 }
 
 // BusinessReviewsRes is the response payload for Business Reviews API
@@ -60,12 +69,14 @@ type BusinessReviewsRes struct {
 	Reviews           []Review `json:"reviews"`            // A list of up to three reviews of this business
 	Total             int      `json:"total"`              // The total number of reviews that the business has
 	PossibleLanguages []string `json:"possible_languages"` // A list of languages for which the business has at least one review.
+	ResponseMeta
 }
 
 // BusinessTransactionSearchRes is the response payload for Business Transaction Search API
 type BusinessTransactionSearchRes struct {
 	Total      int        `json:"total"`
 	Businesses []Business `json:"businesses"`
+	ResponseMeta
 }
 
 // BusinessTransactionReq defines the function arguments for BusinessTransaction
@@ -80,6 +91,7 @@ type BusinessAutocompleteRes struct {
 	Terms      []Term                 `json:"terms"`      // A list of term autocomplete suggestions based on the input text
 	Businesses []AutocompleteBusiness `json:"businesses"` // A list of business autocomplete suggestions based on the input text
 	Categories []Category             `json:"categories"` // A list of category autocomplete suggestions based on the input text
+	ResponseMeta
 }
 
 // BusinessAutocompleteReq defines the function arguments for AutoComplete
@@ -89,6 +101,40 @@ type BusinessAutocompleteReq struct {
 	Locale string // Optional. Specify the locale to return the autocomplete suggestions in. See the list of supported locales. Defaults to en_US
 }
 
+// BusinessMatchReq is the request payload for Business Match API
+type BusinessMatchReq struct {
+	Name           string  `json:"name"`                       // Required. The name of the business. Maximum length is 64
+	Address1       string  `json:"address1"`                   // Required. The first line of the business's address. Maximum length is 64
+	Address2       string  `json:"address2,omitempty"`         // Optional. The second line of the business's address. Maximum length is 64
+	Address3       string  `json:"address3,omitempty"`         // Optional. The third line of the business's address. Maximum length is 64
+	City           string  `json:"city"`                       // Required. The city of the business. Maximum length is 64
+	State          string  `json:"state"`                      // Required. The ISO 3166-2 (with a few exceptions) state code of this business. Maximum length is 3
+	Country        string  `json:"country"`                    // Required. The ISO 3166-1 alpha-2 country code of this business. Maximum length is 2
+	Latitude       float32 `json:"latitude,omitempty"`         // Optional. The WGS84 latitude of the business in decimal degrees. Must be between -90 and +90
+	Longitude      float32 `json:"longitude,omitempty"`        // Optional. The WGS84 longitude of the business in decimal degrees. Must be between -180 and +180
+	Phone          string  `json:"phone,omitempty"`            // Optional. The phone number of the business which can be submitted as (a) locally formatted with digits only (e.g., 016703080) or (b) internationally formatted with a leading + sign and digits only after (+35316703080). Maximum length is 32
+	PostalCode     string  `json:"postal_code,omitempty"`      // Optional. The postal code of this business, like a Zip code. Maximum length is 12
+	YelpBusinessID string  `json:"yelp_business_id,omitempty"` // Optional. Unique Yelp identifier of the business if available. Used as a hint when finding a matching business
+	Limit          int     `json:"limit,omitempty"`            // Optional. Maximum number of businesses to return, between 1 and 10. Defaults to 3
+	MatchThreshold string  `json:"match_threshold,omitempty"`  // Optional. Specifies whether a match quality threshold should be applied to the matched businesses: none, default or strict. Defaults to default
+}
+
+// BusinessMatchRes is the response payload for Business Match API
+type BusinessMatchRes struct {
+	Businesses []BusinessMatch `json:"businesses"` // The list of matching businesses, best match first
+	ResponseMeta
+}
+
+// BusinessMatch is a business returned by the Business Match API
+type BusinessMatch struct {
+	ID          string                  `json:"id"`          // Unique Yelp ID of this business
+	Alias       string                  `json:"alias"`       // Unique Yelp alias of this business
+	Name        string                  `json:"name"`        // Name of this business
+	Location    LocationBusinessDetails `json:"location"`    // Location of this business, including address, city, state, zip code, and country
+	Coordinates Coordinates             `json:"coordinates"` // Coordinates of this business
+	Phone       string                  `json:"phone"`       // Phone number of this business
+}
+
 // Business is the full data of a specific business from the Yelp Fusion Business API consisting of its ID, Rating, Price, Phone Number, Opening Hours, and etc.
 type Business struct {
 	ID           string      `json:"id"`                 // Unique Yelp ID of this business
@@ -0,0 +1,63 @@
+package yelp
+
+import "time"
+
+// Cache stores successful response bodies. Implementations must be safe for concurrent use.
+// See the cache package for an in-memory LRU and a filesystem implementation.
+type Cache interface {
+	Get(key string) ([]byte, bool)                   // Returns the stored value, or false if it is missing or expired
+	Set(key string, value []byte, ttl time.Duration) // Stores the value for ttl. A ttl of 0 or less never expires
+}
+
+// CacheOptions configures the response cache of a Client.
+// Only 200 responses that decode successfully are cached; errors are never cached.
+type CacheOptions struct {
+	Cache      Cache                    // Required. Backend responses are stored in
+	DefaultTTL time.Duration            // Optional. Time to live of cached responses. Responses aren't cached when 0, unless a TTL is set for the operation
+	TTLs       map[string]time.Duration // Optional. Time to live per operation, overriding DefaultTTL, for example {OPERATION_BUSINESS_DETAILS: 24 * time.Hour}. A negative TTL disables caching for the operation
+}
+
+// CacheStats counts the cache lookups of a Client.
+type CacheStats struct {
+	Hits   uint64 // Number of responses served from the cache
+	Misses uint64 // Number of cacheable requests that were sent to Yelp
+}
+
+// ttl returns the time to live for responses of the operation, or 0 if they aren't cached.
+func (o *CacheOptions) ttl(operation string) time.Duration {
+	if o == nil || o.Cache == nil {
+		return 0
+	}
+
+	ttl, ok := o.TTLs[operation]
+	if !ok {
+		ttl = o.DefaultTTL
+	}
+
+	if ttl < 0 {
+		return 0
+	}
+
+	return ttl
+}
+
+// cacheGet looks the key up in the cache, updating the hit and miss counters.
+func (c *Client) cacheGet(key string) ([]byte, bool) {
+	data, ok := c.Cache.Cache.Get(key)
+
+	if ok {
+		c.cacheHits.Add(1)
+	} else {
+		c.cacheMisses.Add(1)
+	}
+
+	return data, ok
+}
+
+// CacheStats returns the hit and miss counters of the response cache.
+func (c *Client) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   c.cacheHits.Load(),
+		Misses: c.cacheMisses.Load(),
+	}
+}
@@ -0,0 +1,68 @@
+package cache_test
+
+import (
+	"github.com/naguigui/yelp-fusion/yelp/cache"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	// Arrange
+	c := cache.NewLRU(2)
+
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), 0)
+	c.Get("a")
+
+	// Act
+	c.Set("c", []byte("3"), 0)
+
+	// Assert
+	_, hasA := c.Get("a")
+	_, hasB := c.Get("b")
+	value, hasC := c.Get("c")
+	assert.True(t, hasA)
+	assert.False(t, hasB)
+	assert.True(t, hasC)
+	assert.Equal(t, []byte("3"), value)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	// Arrange
+	c := cache.NewLRU(10)
+
+	c.Set("a", []byte("1"), time.Millisecond)
+
+	// Act
+	time.Sleep(5 * time.Millisecond)
+	_, ok := c.Get("a")
+
+	// Assert
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestFileStoresAndExpiresEntries(t *testing.T) {
+	// Arrange
+	c, err := cache.NewFile(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("/businesses/abc?locale=en_CA", []byte(`{"id":"abc"}`), time.Hour)
+	c.Set("/businesses/def", []byte(`{"id":"def"}`), time.Millisecond)
+
+	// Act
+	value, ok := c.Get("/businesses/abc?locale=en_CA")
+	time.Sleep(5 * time.Millisecond)
+	_, expiredOk := c.Get("/businesses/def")
+	_, missingOk := c.Get("/businesses/ghi")
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, []byte(`{"id":"abc"}`), value)
+	assert.False(t, expiredOk)
+	assert.False(t, missingOk)
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// File is a cache storing every entry in its own file in a directory, so cached responses survive restarts.
+// Each file holds the expiry time as 8 bytes of Unix nanoseconds followed by the value.
+type File struct {
+	dir string
+}
+
+// NewFile creates a filesystem cache in dir, creating the directory if needed.
+func NewFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &File{dir: dir}, nil
+}
+
+// path returns the file an entry is stored in. Keys are hashed since they contain characters not allowed in file names.
+func (c *File) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the value stored for key, or false if it is missing, expired or unreadable.
+func (c *File) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+
+	if expires := int64(binary.BigEndian.Uint64(data[:8])); expires != 0 && time.Now().UnixNano() > expires {
+		os.Remove(path)
+		return nil, false
+	}
+
+	return data[8:], true
+}
+
+// Set stores value for key during ttl. The file is written to a temporary file first and renamed,
+// so concurrent readers never see a partially written entry. Write errors are ignored as the entry is then simply missed.
+func (c *File) Set(key string, value []byte, ttl time.Duration) {
+	var expires int64
+	if ttl > 0 {
+		expires = time.Now().Add(ttl).UnixNano()
+	}
+
+	tmp, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint64(header, uint64(expires))
+
+	if _, err = tmp.Write(append(header, value...)); err != nil {
+		tmp.Close()
+		return
+	}
+
+	if err = tmp.Close(); err != nil {
+		return
+	}
+
+	os.Rename(tmp.Name(), c.path(key))
+}
@@ -0,0 +1,104 @@
+package yelp_test
+
+import (
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/cache"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheServesRepeatedRequests(t *testing.T) {
+	// Arrange
+	requests := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client, _ := yelp.Init(&yelp.ClientOptions{
+		APIKey: "yelp-key",
+		Cache: &yelp.CacheOptions{
+			Cache: cache.NewLRU(100),
+			TTLs:  map[string]time.Duration{yelp.OPERATION_BUSINESS_REVIEWS: time.Hour},
+		},
+	})
+	client.BaseURI = ts.URL
+
+	// Act
+	first, err := client.BusinessReviews("review12345", "en_CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := client.BusinessReviews("review12345", "en_CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.BusinessReviews("review12345", "fr_CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	assert.Equal(t, first.Reviews, second.Reviews)
+	assert.False(t, first.CacheHit)
+	assert.True(t, second.CacheHit)
+	assert.Equal(t, yelp.RateLimitInfo{}, second.RateLimit)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, yelp.CacheStats{Hits: 1, Misses: 2}, client.CacheStats())
+}
+
+func TestCacheSkipsFailedResponsesAndUncachedOperations(t *testing.T) {
+	// Arrange
+	requests := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/autocomplete" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(200)
+			fmt.Fprint(w, AUTOCOMPLETE_RESPONSE)
+			return
+		}
+		w.WriteHeader(500)
+	}))
+
+	defer ts.Close()
+
+	client, _ := yelp.Init(&yelp.ClientOptions{
+		APIKey: "yelp-key",
+		Cache: &yelp.CacheOptions{
+			Cache:      cache.NewLRU(100),
+			DefaultTTL: time.Hour,
+			TTLs:       map[string]time.Duration{yelp.OPERATION_AUTOCOMPLETE: -1},
+		},
+	})
+	client.BaseURI = ts.URL
+
+	params := yelp.BusinessAutocompleteReq{
+		Coordinates: yelp.Coordinates{Latitude: 43.64784, Longitude: -79.38872},
+		Text:        "thai",
+	}
+
+	// Act
+	_, firstErr := client.BusinessReviews("review12345", "")
+	_, secondErr := client.BusinessReviews("review12345", "")
+	client.Autocomplete(params)
+	client.Autocomplete(params)
+
+	// Assert
+	assert.Error(t, firstErr)
+	assert.Error(t, secondErr)
+	assert.Equal(t, 4, requests)
+	assert.Equal(t, yelp.CacheStats{Hits: 0, Misses: 2}, client.CacheStats())
+}
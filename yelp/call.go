@@ -0,0 +1,119 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// callStats collects what happened while dispatching a call, for its hooks and log record.
+type callStats struct {
+	attempts atomic.Int32 // Incremented by send, possibly from the goroutine of a coalesced call
+	cacheHit bool
+}
+
+// retries returns the number of attempts made after the first one.
+func (s *callStats) retries() int {
+	if attempts := int(s.attempts.Load()); attempts > 1 {
+		return attempts - 1
+	}
+
+	return 0
+}
+
+// callStatsKey is the context key holding the *callStats of a call.
+type callStatsKey struct{}
+
+// countAttempt records an attempt of the call the context belongs to, if it is observed.
+func countAttempt(ctx context.Context) {
+	if stats, ok := ctx.Value(callStatsKey{}).(*callStats); ok {
+		stats.attempts.Add(1)
+	}
+}
+
+// markCacheHit records that the call the context belongs to was served from the cache, if it is observed.
+func markCacheHit(ctx context.Context) {
+	if stats, ok := ctx.Value(callStatsKey{}).(*callStats); ok {
+		stats.cacheHit = true
+	}
+}
+
+// callStatus returns the HTTP status code and Yelp error code a call ended with.
+// The status code is 0 when the call failed without a response.
+func callStatus(err error) (int, string) {
+	if err == nil {
+		return http.StatusOK, ""
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode, apiErr.Code
+	}
+
+	return 0, ""
+}
+
+// CallHooks observes the calls of a Client, for example to trace them or record metrics. See the otelyelp package.
+// Unlike middlewares, which run once per attempt, hooks see a call once, including its retries and cache hits.
+// Start hooks run in the order they were given and End hooks in the reverse order, so each End sees the context of its Start.
+type CallHooks struct {
+	Start     func(ctx context.Context, operation string) context.Context // Optional. Called when a call starts. The returned context is used for the call and given to End
+	End       func(ctx context.Context, call CallInfo)                    // Optional. Called when a call ends, with the context returned by Start
+	RateLimit func(ctx context.Context, info RateLimitInfo)               // Optional. Called for every response carrying RateLimit headers
+}
+
+// CallInfo describes how a call went, as given to CallHooks.End.
+type CallInfo struct {
+	Operation  string        // Name of the operation, for example OPERATION_BUSINESS_SEARCH
+	Duration   time.Duration // Duration of the call, including retries
+	Retries    int           // Number of attempts made after the first one
+	CacheHit   bool          // Whether the response was served from the cache
+	StatusCode int           // HTTP status code of the response, 0 when the call failed without a response
+	ErrorCode  string        // Yelp error code, like BUSINESS_NOT_FOUND, when the call failed with one
+	Err        error         // Error the call failed with, if any
+}
+
+// startCall starts observing a call to the operation when hooks or logging are enabled.
+// The returned function calls the End hooks and logs the call.
+func (c *Client) startCall(ctx context.Context, operation string, req *http.Request) (context.Context, func(err error)) {
+	if len(c.hooks) == 0 && c.logger == nil {
+		return ctx, func(error) {}
+	}
+
+	start := time.Now()
+	stats := &callStats{}
+
+	for _, h := range c.hooks {
+		if h.Start != nil {
+			ctx = h.Start(ctx, operation)
+		}
+	}
+
+	ctx = context.WithValue(ctx, callStatsKey{}, stats)
+
+	return ctx, func(err error) {
+		elapsed := time.Since(start)
+		statusCode, errorCode := callStatus(err)
+		info := CallInfo{
+			Operation:  operation,
+			Duration:   elapsed,
+			Retries:    stats.retries(),
+			CacheHit:   stats.cacheHit,
+			StatusCode: statusCode,
+			ErrorCode:  errorCode,
+			Err:        err,
+		}
+
+		for i := len(c.hooks) - 1; i >= 0; i-- {
+			if c.hooks[i].End != nil {
+				c.hooks[i].End(ctx, info)
+			}
+		}
+
+		if c.logger != nil {
+			c.logCall(ctx, operation, req, stats, elapsed, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package yelp_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type hookKey struct{}
+
+func TestCallHooks(t *testing.T) {
+	// Arrange
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("RateLimit-Remaining", "4999")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+	defer ts.Close()
+
+	var events []string
+	var calls []yelp.CallInfo
+	var quota []int
+	hooks := func(name string) yelp.CallHooks {
+		return yelp.CallHooks{
+			Start: func(ctx context.Context, operation string) context.Context {
+				events = append(events, "start "+name)
+				return context.WithValue(ctx, hookKey{}, name)
+			},
+			End: func(ctx context.Context, call yelp.CallInfo) {
+				events = append(events, fmt.Sprintf("end %s in ctx of %v", name, ctx.Value(hookKey{})))
+				calls = append(calls, call)
+			},
+		}
+	}
+
+	client, _ := yelp.Init(&yelp.ClientOptions{
+		APIKey:      "yelp-key",
+		RetryPolicy: &yelp.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		CallHooks: []yelp.CallHooks{
+			hooks("outer"),
+			hooks("inner"),
+			{RateLimit: func(ctx context.Context, info yelp.RateLimitInfo) { quota = append(quota, info.Remaining) }},
+		},
+	})
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start outer", "start inner", "end inner in ctx of inner", "end outer in ctx of inner"}, events)
+	assert.Equal(t, []int{4999}, quota)
+
+	assert.Len(t, calls, 2)
+	assert.Equal(t, yelp.OPERATION_BUSINESS_REVIEWS, calls[0].Operation)
+	assert.Equal(t, 1, calls[0].Retries)
+	assert.Equal(t, 200, calls[0].StatusCode)
+	assert.False(t, calls[0].CacheHit)
+	assert.NoError(t, calls[0].Err)
+	assert.True(t, calls[0].Duration > 0)
+}
@@ -0,0 +1,216 @@
+package yelp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CategoriesRes is the response payload for All Categories API
+type CategoriesRes struct {
+	Categories []CategoryInfo `json:"categories"` // List of all Yelp business categories
+	ResponseMeta
+}
+
+// CategoryDetailsRes is the response payload for Category Details API
+type CategoryDetailsRes struct {
+	Category CategoryInfo `json:"category"` // Details of the requested category
+	ResponseMeta
+}
+
+// CategoryInfo is the full data of a Yelp business category, including where it sits in the category hierarchy
+type CategoryInfo struct {
+	Alias            string   `json:"alias"`             // Alias of the category, used when searching for businesses in this category
+	Title            string   `json:"title"`             // Title of the category, for display purposes
+	ParentAliases    []string `json:"parent_aliases"`    // Aliases of the parent categories. Empty for top level categories
+	CountryWhitelist []string `json:"country_whitelist"` // ISO 3166-1 alpha-2 codes of the countries this category is available in. Empty if not restricted
+	CountryBlacklist []string `json:"country_blacklist"` // ISO 3166-1 alpha-2 codes of the countries this category is not available in
+}
+
+// AvailableIn reports whether the category is available in the given ISO 3166-1 alpha-2 country code.
+func (c CategoryInfo) AvailableIn(country string) bool {
+	country = strings.ToUpper(country)
+
+	for _, blacklisted := range c.CountryBlacklist {
+		if blacklisted == country {
+			return false
+		}
+	}
+
+	if len(c.CountryWhitelist) == 0 {
+		return true
+	}
+
+	for _, whitelisted := range c.CountryWhitelist {
+		if whitelisted == country {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CategoryTree is an in-memory index of Yelp categories supporting parent/child lookups and alias validation.
+// It is built once, for example from the All Categories API, and is safe for concurrent reads.
+type CategoryTree struct {
+	aliases    []string
+	categories map[string]CategoryInfo
+	children   map[string][]string
+	roots      []string
+}
+
+// NewCategoryTree builds a CategoryTree from a list of categories.
+// Parent aliases that aren't part of the list are ignored.
+func NewCategoryTree(categories []CategoryInfo) *CategoryTree {
+	t := &CategoryTree{
+		categories: make(map[string]CategoryInfo, len(categories)),
+		children:   make(map[string][]string),
+	}
+
+	for _, c := range categories {
+		if _, ok := t.categories[c.Alias]; !ok {
+			t.aliases = append(t.aliases, c.Alias)
+		}
+		t.categories[c.Alias] = c
+	}
+
+	for _, alias := range t.aliases {
+		c := t.categories[alias]
+		root := true
+
+		for _, parent := range c.ParentAliases {
+			if _, ok := t.categories[parent]; ok {
+				t.children[parent] = append(t.children[parent], c.Alias)
+				root = false
+			}
+		}
+
+		if root {
+			t.roots = append(t.roots, c.Alias)
+		}
+	}
+
+	return t
+}
+
+// Len returns the number of categories in the tree.
+func (t *CategoryTree) Len() int {
+	return len(t.categories)
+}
+
+// Lookup returns the category with the given alias.
+func (t *CategoryTree) Lookup(alias string) (CategoryInfo, bool) {
+	c, ok := t.categories[alias]
+	return c, ok
+}
+
+// Roots returns the top level categories.
+func (t *CategoryTree) Roots() []CategoryInfo {
+	return t.resolve(t.roots)
+}
+
+// Parents returns the direct parents of the category with the given alias.
+func (t *CategoryTree) Parents(alias string) []CategoryInfo {
+	return t.resolve(t.categories[alias].ParentAliases)
+}
+
+// Children returns the direct children of the category with the given alias.
+func (t *CategoryTree) Children(alias string) []CategoryInfo {
+	return t.resolve(t.children[alias])
+}
+
+// Descendants returns every category below the one with the given alias, depth first.
+func (t *CategoryTree) Descendants(alias string) []CategoryInfo {
+	var res []CategoryInfo
+	seen := make(map[string]bool)
+
+	var walk func(alias string)
+	walk = func(alias string) {
+		for _, child := range t.children[alias] {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			res = append(res, t.categories[child])
+			walk(child)
+		}
+	}
+	walk(alias)
+
+	return res
+}
+
+// IsDescendant reports whether the category with the given alias sits anywhere below ancestor.
+func (t *CategoryTree) IsDescendant(alias string, ancestor string) bool {
+	seen := make(map[string]bool)
+	queue := append([]string(nil), t.categories[alias].ParentAliases...)
+
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+
+		if parent == ancestor {
+			return true
+		}
+
+		if seen[parent] {
+			continue
+		}
+		seen[parent] = true
+		queue = append(queue, t.categories[parent].ParentAliases...)
+	}
+
+	return false
+}
+
+// FilterByCountry returns a new tree only holding the categories available in the given country.
+func (t *CategoryTree) FilterByCountry(country string) *CategoryTree {
+	var categories []CategoryInfo
+
+	for _, alias := range t.aliases {
+		if c := t.categories[alias]; c.AvailableIn(country) {
+			categories = append(categories, c)
+		}
+	}
+
+	return NewCategoryTree(categories)
+}
+
+// Validate checks that every alias exists in the tree. Surrounding whitespace is ignored.
+func (t *CategoryTree) Validate(aliases ...string) error {
+	var unknown []string
+
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			continue
+		}
+
+		if _, ok := t.categories[alias]; !ok {
+			unknown = append(unknown, alias)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown category aliases: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// ValidateSearch checks that every category of the search request exists in the tree.
+func (t *CategoryTree) ValidateSearch(b BusinessSearchReq) error {
+	return t.Validate(b.Categories...)
+}
+
+// resolve maps aliases to their categories, skipping unknown aliases.
+func (t *CategoryTree) resolve(aliases []string) []CategoryInfo {
+	var res []CategoryInfo
+
+	for _, alias := range aliases {
+		if c, ok := t.categories[alias]; ok {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,171 @@
+package yelp_test
+
+import (
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const CATEGORIES_RESPONSE = `
+{
+	"categories": [
+	  {
+		"alias": "restaurants",
+		"title": "Restaurants",
+		"parent_aliases": [],
+		"country_whitelist": [],
+		"country_blacklist": []
+	  },
+	  {
+		"alias": "japanese",
+		"title": "Japanese",
+		"parent_aliases": ["restaurants"],
+		"country_whitelist": [],
+		"country_blacklist": []
+	  },
+	  {
+		"alias": "sushi",
+		"title": "Sushi Bars",
+		"parent_aliases": ["japanese"],
+		"country_whitelist": [],
+		"country_blacklist": []
+	  },
+	  {
+		"alias": "poutineries",
+		"title": "Poutineries",
+		"parent_aliases": ["restaurants"],
+		"country_whitelist": ["CA"],
+		"country_blacklist": []
+	  },
+	  {
+		"alias": "bubbletea",
+		"title": "Bubble Tea",
+		"parent_aliases": ["restaurants"],
+		"country_whitelist": [],
+		"country_blacklist": ["IT"]
+	  }
+	]
+}
+`
+
+const CATEGORY_DETAILS_RESPONSE = `
+{
+	"category": {
+		"alias": "sushi",
+		"title": "Sushi Bars",
+		"parent_aliases": ["japanese"],
+		"country_whitelist": [],
+		"country_blacklist": []
+	}
+}
+`
+
+func setupCategoryTree(t *testing.T) *yelp.CategoryTree {
+	client := setup()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, CATEGORIES_RESPONSE)
+	}))
+	t.Cleanup(ts.Close)
+
+	client.BaseURI = ts.URL
+
+	tree, err := client.CategoryTree("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tree
+}
+
+func TestCategoryDetailsSuccess(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var path string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, CATEGORY_DETAILS_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	expected := yelp.CategoryDetailsRes{
+		Category: yelp.CategoryInfo{
+			Alias:            "sushi",
+			Title:            "Sushi Bars",
+			ParentAliases:    []string{"japanese"},
+			CountryWhitelist: []string{},
+			CountryBlacklist: []string{},
+		},
+	}
+
+	// Act
+	res, err := client.CategoryDetails("sushi", "en_CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	assert.Equal(t, res, expected)
+	assert.Equal(t, "/categories/sushi", path)
+}
+
+func TestCategoryTreeLookups(t *testing.T) {
+	// Arrange
+	tree := setupCategoryTree(t)
+
+	// Act
+	roots := tree.Roots()
+	children := tree.Children("restaurants")
+	parents := tree.Parents("sushi")
+	descendants := tree.Descendants("restaurants")
+
+	// Assert
+	assert.Equal(t, 5, tree.Len())
+	assert.Len(t, roots, 1)
+	assert.Equal(t, "restaurants", roots[0].Alias)
+	assert.Len(t, children, 3)
+	assert.Equal(t, "japanese", parents[0].Alias)
+	assert.Len(t, descendants, 4)
+	assert.True(t, tree.IsDescendant("sushi", "restaurants"))
+	assert.False(t, tree.IsDescendant("restaurants", "sushi"))
+}
+
+func TestCategoryTreeFilterByCountry(t *testing.T) {
+	// Arrange
+	tree := setupCategoryTree(t)
+
+	// Act
+	italy := tree.FilterByCountry("IT")
+	canada := tree.FilterByCountry("ca")
+
+	// Assert
+	assert.Equal(t, 3, italy.Len())
+	_, hasPoutine := italy.Lookup("poutineries")
+	assert.False(t, hasPoutine)
+	assert.Equal(t, 5, canada.Len())
+}
+
+func TestCategoryTreeValidate(t *testing.T) {
+	// Arrange
+	tree := setupCategoryTree(t)
+
+	// Act
+	valid := tree.ValidateSearch(yelp.BusinessSearchReq{Categories: []string{"sushi", "japanese"}})
+	invalid := tree.ValidateSearch(yelp.BusinessSearchReq{Categories: []string{"sushi", "pizzza", "burgerz"}})
+
+	// Assert
+	assert.NoError(t, valid)
+	assert.EqualError(t, invalid, "unknown category aliases: pizzza, burgerz")
+}
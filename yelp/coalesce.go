@@ -0,0 +1,95 @@
+package yelp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// flightGroup de-duplicates concurrent identical requests so they share a single upstream call.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is an upstream call in progress, along with its result once done is closed.
+type flightCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc // Cancels the upstream call once every waiter gave up
+	waiters int                // Number of callers waiting for the result, guarded by the flightGroup mutex
+	res     *http.Response
+	data    []byte
+	err     error
+}
+
+// do runs fn once for every group of concurrent callers sharing the same key.
+// fn runs with its own context, detached from the caller's cancellation so one caller giving up
+// doesn't fail the others. Each caller stops waiting when its own context is done, and the upstream
+// call is cancelled once the last waiter gave up, so it doesn't keep retrying or waiting for quota in the background.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+	g.mu.Lock()
+
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+
+	call, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+
+		go func() {
+			call.res, call.data, call.err = fn(callCtx)
+			cancel()
+
+			g.mu.Lock()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+
+			close(call.done)
+		}()
+	}
+
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.res, call.data, call.err
+	case <-ctx.Done():
+		g.leave(key, call)
+		return nil, nil, ctx.Err()
+	}
+}
+
+// leave removes a waiter of the call, cancelling it when none is left. The call is forgotten right away,
+// so later identical requests start a new upstream call instead of joining the cancelled one.
+func (g *flightGroup) leave(key string, call *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+
+	call.cancel()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// sendCoalesced sends the request, sharing the upstream call with concurrent identical requests when
+// CoalesceRequests is enabled. Callers must treat the returned response and body as read only.
+func (c *Client) sendCoalesced(req *http.Request, key string) (*http.Response, []byte, error) {
+	if !c.CoalesceRequests {
+		return c.send(req)
+	}
+
+	return c.flights.do(req.Context(), key, func(ctx context.Context) (*http.Response, []byte, error) {
+		return c.send(req.WithContext(ctx))
+	})
+}
@@ -0,0 +1,190 @@
+package yelp_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceRequestsSharesUpstreamCall(t *testing.T) {
+	// Arrange
+	var requests int32
+	release := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client, _ := yelp.Init(&yelp.ClientOptions{APIKey: "yelp-key", CoalesceRequests: true})
+	client.BaseURI = ts.URL
+
+	var wg sync.WaitGroup
+	results := make([]yelp.BusinessReviewsRes, 10)
+	errs := make([]error, 10)
+
+	// Act
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = client.BusinessReviews("review12345", "")
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, 1, results[i].Total)
+	}
+}
+
+func TestCoalesceRequestsSharesErrors(t *testing.T) {
+	// Arrange
+	var requests int32
+	release := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.WriteHeader(500)
+	}))
+
+	defer ts.Close()
+
+	client, _ := yelp.Init(&yelp.ClientOptions{APIKey: "yelp-key", CoalesceRequests: true})
+	client.BaseURI = ts.URL
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+
+	// Act
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.BusinessReviews("review12345", "")
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Follow up calls once the first one is done go upstream again
+	_, laterErr := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	for i := 0; i < 5; i++ {
+		assert.EqualError(t, errs[i], "500 Internal Server Error")
+	}
+	assert.Error(t, laterErr)
+}
+
+func TestCoalesceRequestsAbortsUpstreamWhenEveryCallerCancels(t *testing.T) {
+	// Arrange
+	aborted := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			close(aborted)
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(500)
+		}
+	}))
+
+	defer ts.Close()
+
+	client, _ := yelp.Init(&yelp.ClientOptions{APIKey: "yelp-key", CoalesceRequests: true, RetryPolicy: yelp.DefaultRetryPolicy()})
+	client.BaseURI = ts.URL
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+
+	// Act
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(20*(i+1))*time.Millisecond)
+			defer cancel()
+			_, errs[i] = client.BusinessReviewsWithContext(ctx, "review12345", "")
+		}(i)
+	}
+
+	wg.Wait()
+
+	// Assert
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, errs[i], context.DeadlineExceeded)
+	}
+
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("upstream request wasn't aborted after every caller gave up")
+	}
+}
+
+func TestCoalesceRequestsKeepsUpstreamForRemainingCallers(t *testing.T) {
+	// Arrange
+	var requests int32
+	release := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client, _ := yelp.Init(&yelp.ClientOptions{APIKey: "yelp-key", CoalesceRequests: true})
+	client.BaseURI = ts.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelledErr := make(chan error)
+	go func() {
+		_, err := client.BusinessReviewsWithContext(ctx, "review12345", "")
+		cancelledErr <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	remaining := make(chan error)
+	go func() {
+		_, err := client.BusinessReviews("review12345", "")
+		remaining <- err
+	}()
+
+	// Act
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	firstErr := <-cancelledErr
+	close(release)
+
+	// Assert
+	assert.ErrorIs(t, firstErr, context.Canceled)
+	assert.NoError(t, <-remaining)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,348 @@
+package yelp
+
+import (
+	"context"
+	"math"
+)
+
+const (
+	AREA_CRAWL_DEFAULT_MAX_DEPTH     = 12  // Number of times CrawlArea splits cells at most when none is configured
+	AREA_CRAWL_DEFAULT_MIN_CELL_SIZE = 100 // Width in meters under which CrawlArea stops splitting cells when none is configured
+)
+
+// earthRadius is the mean radius of the Earth in meters.
+const earthRadius = 6371000
+
+// distance returns the great circle distance in meters between two coordinates.
+func distance(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// Area is a geographic area crawled by CrawlArea, such as a BoundingBox or a Polygon.
+type Area interface {
+	Bounds() BoundingBox                       // Smallest bounding box holding the area
+	Contains(latitude, longitude float64) bool // Whether a position is inside the area
+}
+
+// BoundingBox is an area between two latitudes and two longitudes. It doesn't support crossing the antimeridian.
+type BoundingBox struct {
+	South float64 // Minimum latitude
+	West  float64 // Minimum longitude
+	North float64 // Maximum latitude
+	East  float64 // Maximum longitude
+}
+
+// Bounds returns the bounding box itself.
+func (b BoundingBox) Bounds() BoundingBox {
+	return b
+}
+
+// Contains reports whether a position is inside the bounding box, edges included.
+func (b BoundingBox) Contains(latitude, longitude float64) bool {
+	return latitude >= b.South && latitude <= b.North && longitude >= b.West && longitude <= b.East
+}
+
+// center returns the latitude and longitude of the middle of the bounding box.
+func (b BoundingBox) center() (float64, float64) {
+	return (b.South + b.North) / 2, (b.West + b.East) / 2
+}
+
+// width returns the east to west size of the bounding box in meters, measured at its middle latitude.
+func (b BoundingBox) width() float64 {
+	lat, _ := b.center()
+	return distance(lat, b.West, lat, b.East)
+}
+
+// surface returns the approximate surface of the bounding box in square meters.
+func (b BoundingBox) surface() float64 {
+	return b.width() * distance(b.South, b.West, b.North, b.West)
+}
+
+// radius returns the distance in meters from the middle of the bounding box to its corners, so a search circle covers it.
+func (b BoundingBox) radius() float64 {
+	lat, lng := b.center()
+	return math.Max(distance(lat, lng, b.North, b.East), distance(lat, lng, b.South, b.East))
+}
+
+// quarters splits the bounding box in four.
+func (b BoundingBox) quarters() []BoundingBox {
+	lat, lng := b.center()
+
+	return []BoundingBox{
+		{South: lat, West: b.West, North: b.North, East: lng},
+		{South: lat, West: lng, North: b.North, East: b.East},
+		{South: b.South, West: b.West, North: lat, East: lng},
+		{South: b.South, West: lng, North: lat, East: b.East},
+	}
+}
+
+// Polygon is an area delimited by a ring of vertices, implicitly closed from the last vertex back to the first.
+type Polygon []Coordinates
+
+// Bounds returns the smallest bounding box holding the vertices.
+func (p Polygon) Bounds() BoundingBox {
+	if len(p) == 0 {
+		return BoundingBox{}
+	}
+
+	b := BoundingBox{South: math.Inf(1), West: math.Inf(1), North: math.Inf(-1), East: math.Inf(-1)}
+	for _, v := range p {
+		b.South = math.Min(b.South, float64(v.Latitude))
+		b.North = math.Max(b.North, float64(v.Latitude))
+		b.West = math.Min(b.West, float64(v.Longitude))
+		b.East = math.Max(b.East, float64(v.Longitude))
+	}
+
+	return b
+}
+
+// Contains reports whether a position is inside the polygon, using the even-odd rule.
+func (p Polygon) Contains(latitude, longitude float64) bool {
+	inside := false
+
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		yi, xi := float64(p[i].Latitude), float64(p[i].Longitude)
+		yj, xj := float64(p[j].Latitude), float64(p[j].Longitude)
+
+		if (yi > latitude) != (yj > latitude) && longitude < (xj-xi)*(latitude-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+// intersects reports whether the polygon overlaps a cell, so CrawlArea skips cells outside it.
+func (p Polygon) intersects(cell BoundingBox) bool {
+	corners := [][2]float64{{cell.South, cell.West}, {cell.South, cell.East}, {cell.North, cell.East}, {cell.North, cell.West}}
+
+	for _, c := range corners {
+		if p.Contains(c[0], c[1]) {
+			return true
+		}
+	}
+
+	for i := range p {
+		a, b := p[i], p[(i+1)%len(p)]
+		if cell.Contains(float64(a.Latitude), float64(a.Longitude)) {
+			return true
+		}
+
+		for k := range corners {
+			if segmentsCross(a, b, corners[k], corners[(k+1)%len(corners)]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// segmentsCross reports whether the polygon edge from a to b crosses the cell edge from c to d.
+func segmentsCross(a Coordinates, b Coordinates, c [2]float64, d [2]float64) bool {
+	orientation := func(p, q, r [2]float64) float64 {
+		return (q[1]-p[1])*(r[0]-p[0]) - (q[0]-p[0])*(r[1]-p[1])
+	}
+
+	pa := [2]float64{float64(a.Latitude), float64(a.Longitude)}
+	pb := [2]float64{float64(b.Latitude), float64(b.Longitude)}
+
+	return orientation(pa, pb, c)*orientation(pa, pb, d) < 0 && orientation(c, d, pa)*orientation(c, d, pb) < 0
+}
+
+// AreaCrawlOptions configures how CrawlArea splits the area.
+type AreaCrawlOptions struct {
+	MaxDepth    int     // Optional. Number of times a cell is split in four at most. Defaults to 12
+	MinCellSize float64 // Optional. Width in meters under which cells aren't split anymore. Defaults to 100
+	CellCap     int     // Optional. Number of results above which a cell is split. Defaults to BUSINESS_SEARCH_MAX_RESULTS, the most a single search returns
+}
+
+// AreaCrawlStats reports how a CrawlArea went and how completely it covered the area.
+type AreaCrawlStats struct {
+	Cells      int     // Number of cells searched
+	Split      int     // Number of cells split in four because they matched more than the cap or exceeded the maximum search radius
+	Saturated  int     // Number of cells matching more than the cap that couldn't be split further. Some of their businesses are missing
+	Partial    int     // Number of cells wider than the maximum search radius that couldn't be split further. Businesses near their corners are missing
+	Skipped    int     // Number of cells outside the area that weren't searched
+	Requests   int     // Number of search requests sent
+	Returned   int     // Number of businesses returned by the searches, including duplicates and businesses outside the area
+	Duplicates int     // Number of businesses returned again by overlapping cells
+	Outside    int     // Number of businesses dropped because they are outside the area
+	Coverage   float64 // Fraction of the searched surface, from 0 to 1, in cells whose every result was fetched and fully inside their search circle
+}
+
+// AreaCrawlRes is the result of CrawlArea.
+type AreaCrawlRes struct {
+	Businesses []Business     // Businesses inside the area, de-duplicated by ID, in the order they were found
+	Stats      AreaCrawlStats // Statistics of the crawl
+}
+
+// areaCrawl holds the state of a CrawlArea.
+type areaCrawl struct {
+	client   *Client
+	area     Area
+	template BusinessSearchReq
+	options  AreaCrawlOptions
+	seen     map[string]struct{}
+	res      *AreaCrawlRes
+
+	searched float64
+	covered  float64
+}
+
+// CrawlArea finds the businesses of an area too large for the 1000 results a single Business Search returns.
+// It searches the bounding box of the area with the template request, splitting it in four cells and searching them in turn
+// while a cell matches more than the cap, with Latitude, Longitude and Radius set to a circle covering each cell.
+// Businesses are de-duplicated by ID and those outside the area are dropped.
+//
+// The template request sets the other filters, such as Term or Categories. Its Location, Limit and Offset are ignored.
+// When ctx is done or a search fails, the businesses found so far are returned with the error. Options may be nil.
+func (c *Client) CrawlArea(ctx context.Context, area Area, template BusinessSearchReq, opts *AreaCrawlOptions) (*AreaCrawlRes, error) {
+	var options AreaCrawlOptions
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.MaxDepth <= 0 {
+		options.MaxDepth = AREA_CRAWL_DEFAULT_MAX_DEPTH
+	}
+	if options.MinCellSize <= 0 {
+		options.MinCellSize = AREA_CRAWL_DEFAULT_MIN_CELL_SIZE
+	}
+	if options.CellCap <= 0 || options.CellCap > BUSINESS_SEARCH_MAX_RESULTS {
+		options.CellCap = BUSINESS_SEARCH_MAX_RESULTS
+	}
+
+	template.Location = ""
+	template.Offset = 0
+	template.Limit = BUSINESS_SEARCH_MAX_LIMIT
+	if options.CellCap < template.Limit {
+		template.Limit = options.CellCap
+	}
+
+	crawl := &areaCrawl{
+		client:   c,
+		area:     area,
+		template: template,
+		options:  options,
+		seen:     make(map[string]struct{}),
+		res:      &AreaCrawlRes{Businesses: []Business{}},
+	}
+
+	err := crawl.cell(ctx, area.Bounds(), 0)
+
+	if crawl.searched > 0 {
+		crawl.res.Stats.Coverage = crawl.covered / crawl.searched
+	}
+
+	return crawl.res, err
+}
+
+// cell searches a cell, splitting it when it's too large for a search radius or matches more than the cap.
+func (a *areaCrawl) cell(ctx context.Context, cell BoundingBox, depth int) error {
+	if p, ok := a.area.(interface{ intersects(BoundingBox) bool }); ok && !p.intersects(cell) {
+		a.res.Stats.Skipped++
+		return nil
+	}
+
+	canSplit := depth < a.options.MaxDepth && cell.width()/2 >= a.options.MinCellSize
+	radius := math.Ceil(cell.radius())
+
+	if radius > BUSINESS_SEARCH_MAX_RADIUS && depth < a.options.MaxDepth {
+		return a.split(ctx, cell, depth)
+	}
+
+	req := a.template
+	lat, lng := cell.center()
+	req.Latitude, req.Longitude = float32(lat), float32(lng)
+	req.Radius = int(math.Max(1, math.Min(radius, BUSINESS_SEARCH_MAX_RADIUS)))
+
+	res, err := a.search(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if res.Total > a.options.CellCap && canSplit {
+		return a.split(ctx, cell, depth)
+	}
+
+	a.res.Stats.Cells++
+	surface := cell.surface()
+	a.searched += surface
+
+	switch {
+	case res.Total > a.options.CellCap:
+		a.res.Stats.Saturated++
+	case radius > BUSINESS_SEARCH_MAX_RADIUS:
+		a.res.Stats.Partial++
+	default:
+		a.covered += surface
+	}
+
+	end := res.Total
+	if end > a.options.CellCap {
+		end = a.options.CellCap
+	}
+
+	for {
+		a.add(res.Businesses)
+		req.Offset += len(res.Businesses)
+
+		if len(res.Businesses) == 0 || req.Offset >= end {
+			return nil
+		}
+
+		if req.Offset+req.Limit > end {
+			req.Limit = end - req.Offset
+		}
+
+		if res, err = a.search(ctx, req); err != nil {
+			return err
+		}
+	}
+}
+
+// split searches the four quarters of a cell.
+func (a *areaCrawl) split(ctx context.Context, cell BoundingBox, depth int) error {
+	a.res.Stats.Split++
+
+	for _, quarter := range cell.quarters() {
+		if err := a.cell(ctx, quarter, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// search sends a search request and counts it.
+func (a *areaCrawl) search(ctx context.Context, req BusinessSearchReq) (BusinessSearchRes, error) {
+	a.res.Stats.Requests++
+	return a.client.BusinessSearchWithContext(ctx, req)
+}
+
+// add keeps the businesses not seen yet that are inside the area.
+func (a *areaCrawl) add(businesses []Business) {
+	for _, b := range businesses {
+		a.res.Stats.Returned++
+
+		if _, ok := a.seen[b.ID]; ok {
+			a.res.Stats.Duplicates++
+			continue
+		}
+		a.seen[b.ID] = struct{}{}
+
+		if !a.area.Contains(float64(b.Coordinates.Latitude), float64(b.Coordinates.Longitude)) {
+			a.res.Stats.Outside++
+			continue
+		}
+
+		a.res.Businesses = append(a.res.Businesses, b)
+	}
+}
@@ -0,0 +1,150 @@
+package yelp_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/yelptest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// CRAWL_AREA is a box of about 2.2 km by 1.8 km in San Francisco.
+var CRAWL_AREA = yelp.BoundingBox{South: 37.76, West: -122.44, North: 37.78, East: -122.42}
+
+// gridServer seeds a fake server with size by size businesses spread evenly over the area.
+func gridServer(t *testing.T, area yelp.BoundingBox, size int) *yelptest.Server {
+	server := yelptest.NewServer()
+	t.Cleanup(server.Close)
+
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			server.AddBusinesses(yelp.BusinessDetailsRes{
+				ID: fmt.Sprintf("biz-%d-%d", i, j),
+				Coordinates: yelp.Coordinates{
+					Latitude:  float32(area.South + (float64(i)+0.5)*(area.North-area.South)/float64(size)),
+					Longitude: float32(area.West + (float64(j)+0.5)*(area.East-area.West)/float64(size)),
+				},
+			})
+		}
+	}
+
+	return server
+}
+
+func TestCrawlAreaSplitsCellsOverTheCap(t *testing.T) {
+	// Arrange
+	server := gridServer(t, CRAWL_AREA, 20)
+	client := server.Client(nil)
+
+	// Act
+	res, err := client.CrawlArea(context.Background(), CRAWL_AREA, yelp.BusinessSearchReq{Location: "ignored", Offset: 10}, &yelp.AreaCrawlOptions{CellCap: 60})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.Businesses, 400)
+
+	ids := make(map[string]bool)
+	for _, b := range res.Businesses {
+		ids[b.ID] = true
+	}
+	assert.Len(t, ids, 400)
+
+	assert.True(t, res.Stats.Split > 0)
+	assert.True(t, res.Stats.Cells >= 400/60)
+	assert.True(t, res.Stats.Duplicates > 0)
+	assert.Equal(t, 0, res.Stats.Saturated)
+	assert.Equal(t, 400+res.Stats.Duplicates+res.Stats.Outside, res.Stats.Returned)
+	assert.Equal(t, 1.0, res.Stats.Coverage)
+	assert.Equal(t, len(server.Requests()), res.Stats.Requests)
+}
+
+func TestCrawlAreaReportsSaturatedCells(t *testing.T) {
+	// Arrange
+	server := yelptest.NewServer()
+	defer server.Close()
+
+	for i := 0; i < 30; i++ {
+		server.AddBusinesses(yelp.BusinessDetailsRes{ID: fmt.Sprintf("biz-%d", i), Coordinates: yelp.Coordinates{Latitude: 37.7613, Longitude: -122.4387}})
+	}
+
+	// Act
+	res, err := server.Client(nil).CrawlArea(context.Background(), CRAWL_AREA, yelp.BusinessSearchReq{}, &yelp.AreaCrawlOptions{CellCap: 10, MaxDepth: 2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, res.Stats.Saturated > 0)
+	assert.True(t, res.Stats.Coverage < 1)
+	assert.True(t, len(res.Businesses) < 30)
+}
+
+func TestCrawlAreaSkipsCellsOutsidePolygon(t *testing.T) {
+	// Arrange
+	server := gridServer(t, CRAWL_AREA, 10)
+
+	triangle := yelp.Polygon{
+		{Latitude: float32(CRAWL_AREA.South), Longitude: float32(CRAWL_AREA.West)},
+		{Latitude: float32(CRAWL_AREA.North), Longitude: float32(CRAWL_AREA.West)},
+		{Latitude: float32(CRAWL_AREA.South), Longitude: float32(CRAWL_AREA.East)},
+	}
+
+	// Act
+	res, err := server.Client(nil).CrawlArea(context.Background(), triangle, yelp.BusinessSearchReq{}, &yelp.AreaCrawlOptions{CellCap: 20})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, res.Stats.Skipped > 0)
+	assert.True(t, res.Stats.Outside > 0)
+	assert.True(t, len(res.Businesses) > 0)
+
+	for _, b := range res.Businesses {
+		assert.True(t, triangle.Contains(float64(b.Coordinates.Latitude), float64(b.Coordinates.Longitude)), b.ID)
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	// Arrange
+	square := yelp.Polygon{{Latitude: 0, Longitude: 0}, {Latitude: 0, Longitude: 2}, {Latitude: 2, Longitude: 2}, {Latitude: 2, Longitude: 0}}
+
+	// Assert
+	assert.True(t, square.Contains(1, 1))
+	assert.False(t, square.Contains(3, 1))
+	assert.False(t, square.Contains(1, -0.5))
+	assert.Equal(t, yelp.BoundingBox{South: 0, West: 0, North: 2, East: 2}, square.Bounds())
+}
+
+func TestCrawlAreaReportsCellsWiderThanTheSearchRadius(t *testing.T) {
+	// Arrange
+	server := gridServer(t, CRAWL_AREA, 2)
+	wide := yelp.BoundingBox{South: 36.8, West: -123.4, North: 38.8, East: -121.4}
+
+	// Act
+	res, err := server.Client(nil).CrawlArea(context.Background(), wide, yelp.BusinessSearchReq{}, &yelp.AreaCrawlOptions{MaxDepth: 1})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Stats.Split)
+	assert.Equal(t, 4, res.Stats.Cells)
+	assert.Equal(t, 4, res.Stats.Partial)
+	assert.Equal(t, 0, res.Stats.Saturated)
+	assert.Equal(t, 0.0, res.Stats.Coverage)
+
+	for _, r := range server.Requests() {
+		assert.Equal(t, "40000", r.URL.Query().Get("radius"))
+	}
+}
+
+func TestCrawlAreaCenteredOnPrimeMeridian(t *testing.T) {
+	// Arrange
+	greenwich := yelp.BoundingBox{South: 51.47, West: -0.01, North: 51.49, East: 0.01}
+	server := gridServer(t, greenwich, 10)
+
+	// Act
+	res, err := server.Client(nil).CrawlArea(context.Background(), greenwich, yelp.BusinessSearchReq{}, &yelp.AreaCrawlOptions{CellCap: 30})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.Businesses, 100)
+	assert.Equal(t, 1.0, res.Stats.Coverage)
+	assert.Equal(t, "0", server.Requests()[0].URL.Query().Get("longitude"))
+}
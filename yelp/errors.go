@@ -0,0 +1,84 @@
+package yelp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors that an *APIError can be matched against with errors.Is
+var (
+	ErrTokenInvalid     = errors.New("yelp: access token is invalid")
+	ErrTooManyRequests  = errors.New("yelp: too many requests")
+	ErrBusinessNotFound = errors.New("yelp: business not found")
+	ErrValidation       = errors.New("yelp: request failed validation")
+
+	// ErrRateLimited is returned without contacting Yelp when a fail fast RateLimiter has no capacity left
+	ErrRateLimited = errors.New("yelp: client side rate limit exceeded")
+)
+
+// APIError is returned when the Yelp Fusion API responds with a non 200 status code.
+// It carries the HTTP status along with the error details parsed from the response body
+type APIError struct {
+	StatusCode  int    // HTTP status code of the response, for example 404
+	Status      string // HTTP status line of the response, for example "404 Not Found"
+	Code        string // Yelp error code, for example BUSINESS_NOT_FOUND. Empty if the body could not be parsed
+	Description string // Human readable description of the error provided by Yelp
+	Field       string // Name of the request field that failed validation, if any
+	Body        []byte // Raw response body
+}
+
+// apiErrorRes is the error payload returned by Yelp, for example {"error": {"code": "...", "description": "..."}}
+type apiErrorRes struct {
+	Error struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+		Field       string `json:"field"`
+	} `json:"error"`
+}
+
+// newAPIError builds an APIError from a non 200 response and its already read body.
+func newAPIError(res *http.Response, body []byte) *APIError {
+	e := &APIError{StatusCode: res.StatusCode, Status: res.Status, Body: body}
+
+	var payload apiErrorRes
+	if err := json.Unmarshal(body, &payload); err == nil {
+		e.Code = payload.Error.Code
+		e.Description = payload.Error.Description
+		e.Field = payload.Error.Field
+	}
+
+	return e
+}
+
+// Error formats the status line followed by the Yelp error code and description when available.
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return e.Status
+	}
+
+	if e.Description == "" {
+		return fmt.Sprintf("%s: %s", e.Status, e.Code)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", e.Status, e.Code, e.Description)
+}
+
+// Is reports whether the error matches one of the package sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrTokenInvalid:
+		return e.Code == "TOKEN_INVALID" || e.Code == "TOKEN_MISSING" || e.Code == "UNAUTHORIZED_ACCESS_TOKEN" ||
+			(e.Code == "" && e.StatusCode == http.StatusUnauthorized)
+	case ErrTooManyRequests:
+		return e.Code == "TOO_MANY_REQUESTS_PER_SECOND" || e.Code == "ACCESS_LIMIT_REACHED" ||
+			e.StatusCode == http.StatusTooManyRequests
+	case ErrBusinessNotFound:
+		return e.Code == "BUSINESS_NOT_FOUND"
+	case ErrValidation:
+		return e.Code == "VALIDATION_ERROR"
+	}
+
+	return false
+}
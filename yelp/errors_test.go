@@ -0,0 +1,109 @@
+package yelp_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const BUSINESS_NOT_FOUND_RESPONSE = `
+{
+	"error": {
+		"code": "BUSINESS_NOT_FOUND",
+		"description": "The requested business could not be found."
+	}
+}
+`
+
+const VALIDATION_ERROR_RESPONSE = `
+{
+	"error": {
+		"code": "VALIDATION_ERROR",
+		"description": "1000 is greater than the maximum of 50",
+		"field": "limit"
+	}
+}
+`
+
+func TestAPIErrorBusinessNotFound(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(404)
+		fmt.Fprint(w, BUSINESS_NOT_FOUND_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessDetails("unknown-id", "")
+
+	// Assert
+	var apiErr *yelp.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 404, apiErr.StatusCode)
+	assert.Equal(t, "BUSINESS_NOT_FOUND", apiErr.Code)
+	assert.Equal(t, "The requested business could not be found.", apiErr.Description)
+	assert.True(t, errors.Is(err, yelp.ErrBusinessNotFound))
+	assert.False(t, errors.Is(err, yelp.ErrValidation))
+	assert.EqualError(t, err, "404 Not Found: BUSINESS_NOT_FOUND: The requested business could not be found.")
+}
+
+func TestAPIErrorValidation(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(400)
+		fmt.Fprint(w, VALIDATION_ERROR_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	params := yelp.BusinessSearchReq{
+		Term:     "restaurant",
+		Location: "222 Yonge St. Toronto, ON",
+	}
+
+	// Act
+	_, err := client.BusinessSearch(params)
+
+	// Assert
+	var apiErr *yelp.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "limit", apiErr.Field)
+	assert.Equal(t, []byte(VALIDATION_ERROR_RESPONSE), apiErr.Body)
+	assert.True(t, errors.Is(err, yelp.ErrValidation))
+}
+
+func TestAPIErrorTooManyRequests(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(429)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.True(t, errors.Is(err, yelp.ErrTooManyRequests))
+	assert.False(t, errors.Is(err, yelp.ErrTokenInvalid))
+	assert.EqualError(t, err, "429 Too Many Requests")
+}
@@ -0,0 +1,76 @@
+package yelp
+
+const (
+	EVENT_SEARCH_MAX_LIMIT  = 50    // Maximum number of events Yelp returns in a single Event Search page
+	EVENT_SEARCH_MAX_RADIUS = 40000 // Maximum event search radius in meters
+)
+
+// EventSearchReq is the request payload for Event Search API
+type EventSearchReq struct {
+	Locale         string  `json:"locale,omitempty"`          // Optional. Specify the locale into which to localize the event information. Defaults to en_US
+	Offset         int     `json:"offset,omitempty"`          // Optional. Offset the list of returned events results by this amount
+	Limit          int     `json:"limit,omitempty"`           // Optional. Number of events results to return. By default, it will return 3. Maximum is 50
+	SortBy         string  `json:"sort_by,omitempty"`         // Optional. Sort by either descending or ascending order: desc or asc. By default, it returns results in descending order
+	SortOn         string  `json:"sort_on,omitempty"`         // Optional. Sort on popularity or time start: popularity or time_start. By default, sorts on popularity
+	StartDate      int64   `json:"start_date,omitempty"`      // Optional. Unix timestamp of the event start time. Will return events that only begin at or after the specified time
+	EndDate        int64   `json:"end_date,omitempty"`        // Optional. Unix timestamp of the event end time. Will return events that only end at or before the specified time
+	Categories     string  `json:"categories,omitempty"`      // Optional. The category filter can be a list of comma delimited categories to get OR'd results that include the categories provided
+	IsFree         *bool   `json:"is_free,omitempty"`         // Optional. Filter whether the events are free to attend. By default no filter is applied so both free and paid events will be returned
+	Location       string  `json:"location,omitempty"`        // Optional. Specifies the combination of "address, neighborhood, city, state or zip, optional country" to be used when searching for events
+	Latitude       float32 `json:"latitude,omitempty"`        // Optional. Latitude of the location you want to search nearby. If latitude is provided, longitude is required too
+	Longitude      float32 `json:"longitude,omitempty"`       // Optional. Longitude of the location you want to search nearby. If longitude is provided, latitude is required too
+	Radius         int     `json:"radius,omitempty"`          // Optional. Search radius in meters. The max value is 40000 meters (25 miles)
+	ExcludedEvents string  `json:"excluded_events,omitempty"` // Optional. List of comma delimited event IDs to exclude from the search results
+}
+
+// EventSearchRes is the response payload for Event Search API
+type EventSearchRes struct {
+	Total  int     `json:"total"`  // Total number of events returned based on search criteria
+	Events []Event `json:"events"` // List of events found matching search criteria
+	ResponseMeta
+}
+
+// FeaturedEventReq is the request payload for Featured Event API
+type FeaturedEventReq struct {
+	Locale    string  `json:"locale,omitempty"`    // Optional. Specify the locale into which to localize the event information. Defaults to en_US
+	Location  string  `json:"location,omitempty"`  // Required if either latitude or longitude is not provided. Specifies the combination of "address, neighborhood, city, state or zip, optional country" to be used when searching for events
+	Latitude  float32 `json:"latitude,omitempty"`  // Required if location is not provided. Latitude of the location you want to search nearby
+	Longitude float32 `json:"longitude,omitempty"` // Required if location is not provided. Longitude of the location you want to search nearby
+}
+
+// EventDetailsRes is the response payload for Event Lookup and Featured Event APIs
+type EventDetailsRes struct {
+	Event
+	ResponseMeta
+}
+
+// Event is the full data of a specific event from the Yelp Fusion Event API
+type Event struct {
+	ID              string        `json:"id"`               // Event id
+	Name            string        `json:"name"`             // Name of this event
+	Description     string        `json:"description"`      // Description excerpt of this event
+	Category        string        `json:"category"`         // The category of this event
+	AttendingCount  int           `json:"attending_count"`  // Number of Yelp users attending this event
+	InterestedCount int           `json:"interested_count"` // Number of Yelp users interested in attending this event
+	Cost            float32       `json:"cost"`             // Cost of attending this event. Zero when the event is free or the cost is unknown
+	CostMax         float32       `json:"cost_max"`         // Maximum cost of this event
+	IsCanceled      bool          `json:"is_canceled"`      // Whether this event is canceled
+	IsFree          bool          `json:"is_free"`          // Whether this event is free
+	IsOfficial      bool          `json:"is_official"`      // Whether this event is created by a Yelp community manager
+	EventSiteURL    string        `json:"event_site_url"`   // Yelp page of this event
+	ImageURL        string        `json:"image_url"`        // Yelp image url of this event
+	TicketsURL      string        `json:"tickets_url"`      // URL to buy tickets for this event
+	TimeStart       string        `json:"time_start"`       // Time this event starts. ISO 8601 format with timezone offset, for example "2016-07-28T21:00:00-07:00"
+	TimeEnd         string        `json:"time_end"`         // Time this event ends. ISO 8601 format with timezone offset. Empty when unknown
+	Latitude        float32       `json:"latitude"`         // Latitude of this event
+	Longitude       float32       `json:"longitude"`        // Longitude of this event
+	Location        EventLocation `json:"location"`         // Location of this event, including address, city, state, zip code and country
+	BusinessID      string        `json:"business_id"`      // Yelp business ID of the venue hosting this event, if any
+}
+
+// EventLocation is the location of an event, including address, city, state, zip code and country
+type EventLocation struct {
+	Location
+	DisplayAddress []string `json:"display_address"` // Array of strings that if organized vertically give an address that is in the standard address format for the event's country
+	CrossStreets   string   `json:"cross_streets"`   // Cross streets for this event
+}
@@ -0,0 +1,182 @@
+package yelp_test
+
+import (
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+const EVENT_JSON = `
+{
+	"attending_count": 12,
+	"category": "music",
+	"cost": 25.5,
+	"cost_max": null,
+	"description": "Live jazz every Friday night",
+	"event_site_url": "https://www.yelp.com/events/toronto-friday-jazz",
+	"id": "toronto-friday-jazz",
+	"image_url": "https://s3-media1.fl.yelpcdn.com/ephoto/jazz/o.jpg",
+	"interested_count": 40,
+	"is_canceled": false,
+	"is_free": false,
+	"is_official": true,
+	"latitude": 43.64784,
+	"longitude": -79.38872,
+	"name": "Friday Jazz",
+	"tickets_url": "https://tickets.example.com/jazz",
+	"time_end": null,
+	"time_start": "2020-05-08T21:00:00-04:00",
+	"location": {
+		"address1": "220 Yonge St",
+		"address2": "",
+		"address3": "",
+		"city": "Toronto",
+		"zip_code": "M5B 2H1",
+		"country": "CA",
+		"state": "ON",
+		"display_address": ["220 Yonge St", "Toronto, ON M5B 2H1"],
+		"cross_streets": "Dundas St"
+	},
+	"business_id": "eaton-centre-toronto"
+}
+`
+
+var EVENT_SEARCH_RESPONSE = fmt.Sprintf(`{"total": 1, "events": [%s]}`, EVENT_JSON)
+
+var expectedEvent = yelp.Event{
+	ID:              "toronto-friday-jazz",
+	Name:            "Friday Jazz",
+	Description:     "Live jazz every Friday night",
+	Category:        "music",
+	AttendingCount:  12,
+	InterestedCount: 40,
+	Cost:            25.5,
+	IsOfficial:      true,
+	EventSiteURL:    "https://www.yelp.com/events/toronto-friday-jazz",
+	ImageURL:        "https://s3-media1.fl.yelpcdn.com/ephoto/jazz/o.jpg",
+	TicketsURL:      "https://tickets.example.com/jazz",
+	TimeStart:       "2020-05-08T21:00:00-04:00",
+	Latitude:        43.64784,
+	Longitude:       -79.38872,
+	Location: yelp.EventLocation{
+		Location: yelp.Location{
+			City:     "Toronto",
+			Country:  "CA",
+			State:    "ON",
+			Address1: "220 Yonge St",
+			ZipCode:  "M5B 2H1",
+		},
+		DisplayAddress: []string{"220 Yonge St", "Toronto, ON M5B 2H1"},
+		CrossStreets:   "Dundas St",
+	},
+	BusinessID: "eaton-centre-toronto",
+}
+
+func TestEventSearchSuccess(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var query url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, EVENT_SEARCH_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	isFree := false
+	params := yelp.EventSearchReq{
+		Location:  "Toronto, ON",
+		StartDate: 1588982400,
+		IsFree:    &isFree,
+		SortOn:    yelp.EVENT_SORT_ON_TIME_START,
+	}
+
+	// Act
+	res, err := client.EventSearch(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	assert.Equal(t, res, yelp.EventSearchRes{Total: 1, Events: []yelp.Event{expectedEvent}})
+	assert.Equal(t, "1588982400", query.Get("start_date"))
+	assert.Equal(t, "false", query.Get("is_free"))
+	assert.Equal(t, "time_start", query.Get("sort_on"))
+}
+
+func TestEventSearchValidation(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	// Act
+	_, err := client.EventSearch(yelp.EventSearchReq{Latitude: 43.64784})
+	_, limitErr := client.EventSearch(yelp.EventSearchReq{Location: "Toronto", Limit: 51})
+	_, radiusErr := client.EventSearch(yelp.EventSearchReq{Location: "Toronto", Radius: 40001})
+	_, sortByErr := client.EventSearch(yelp.EventSearchReq{Location: "Toronto", SortBy: "descending"})
+	_, sortOnErr := client.EventSearch(yelp.EventSearchReq{Location: "Toronto", SortOn: "attending_count"})
+
+	// Assert
+	assert.EqualError(t, err, "latitude and longitude must be provided together")
+	assert.EqualError(t, limitErr, "limit must be between 1 and 50")
+	assert.EqualError(t, radiusErr, "radius must be between 0 and 40000 meters")
+	assert.EqualError(t, sortByErr, "sort_by must be asc or desc, got \"descending\"")
+	assert.EqualError(t, sortOnErr, "sort_on must be popularity or time_start, got \"attending_count\"")
+}
+
+func TestEventLookupSuccess(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var path string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, EVENT_JSON)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	res, err := client.EventLookup("toronto-friday-jazz", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	assert.Equal(t, res, yelp.EventDetailsRes{Event: expectedEvent})
+	assert.Equal(t, "/events/toronto-friday-jazz", path)
+}
+
+func TestFeaturedEventError(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(500)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.FeaturedEvent(yelp.FeaturedEventReq{Location: "Toronto, ON"})
+
+	// Assert
+	assert.EqualError(t, err, "500 Internal Server Error")
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// csvEncoder writes rows as CSV records, after a header record holding the column names.
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+// CSV is the Format writing rows as CSV records, with a header record holding the column names.
+func CSV(w io.Writer, fields []Field) (Encoder, error) {
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.Name
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &csvEncoder{w: cw}, nil
+}
+
+// formatValue formats a field value as text.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// Encode writes a record. Rows are buffered until Close.
+func (e *csvEncoder) Encode(row []interface{}) error {
+	record := make([]string, len(row))
+	for i, v := range row {
+		record[i] = formatValue(v)
+	}
+
+	return e.w.Write(record)
+}
+
+// Close flushes the buffered records.
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
@@ -0,0 +1,150 @@
+// Package export streams Yelp businesses, business details and reviews to tabular formats such as CSV and NDJSON,
+// and businesses to GeoJSON for maps.
+// Nested values like Location, Coordinates and Categories are flattened into columns, for example "location.city".
+// See the parquet sub package for Parquet output.
+//
+//	e, err := export.NewBusinessExporter(file, export.CSV, nil)
+//	n, err := export.ExportSearch(client.NewBusinessSearchIterator(ctx, req, nil), e)
+//	err = e.Close()
+package export
+
+import (
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"io"
+)
+
+// FieldType is the type of the values of a field.
+type FieldType int
+
+const (
+	TYPE_STRING FieldType = iota // Values are strings
+	TYPE_INT                     // Values are int64
+	TYPE_FLOAT                   // Values are float64
+	TYPE_BOOL                    // Values are bool
+)
+
+// Field describes a column of the output: its name and the type of its values.
+type Field struct {
+	Name string
+	Type FieldType
+}
+
+// Column maps a flattened field of the exported records onto an output column.
+type Column struct {
+	Field string // Name of the flattened field, for example "location.city". See BusinessFields, BusinessDetailsFields and ReviewFields
+	Name  string // Optional. Name of the column in the output. Defaults to Field
+}
+
+// Encoder writes rows of values matching the fields it was created with. Close flushes buffered rows without closing the writer.
+type Encoder interface {
+	Encode(row []interface{}) error
+	Close() error
+}
+
+// Format creates an Encoder writing rows of the given fields to w, for example CSV or NDJSON.
+type Format func(w io.Writer, fields []Field) (Encoder, error)
+
+// field is a flattened field of a record type T.
+type field[T any] struct {
+	name  string
+	typ   FieldType
+	value func(record T) interface{}
+}
+
+// Exporter streams records of type T to an Encoder, one row per record.
+type Exporter[T any] struct {
+	enc    Encoder
+	values []func(record T) interface{}
+}
+
+// newExporter resolves the columns against the fields of T and creates the encoder. All fields are exported when columns is empty.
+func newExporter[T any](w io.Writer, format Format, fields []field[T], columns []Column) (*Exporter[T], error) {
+	if len(columns) == 0 {
+		for _, f := range fields {
+			columns = append(columns, Column{Field: f.name})
+		}
+	}
+
+	byName := make(map[string]field[T], len(fields))
+	for _, f := range fields {
+		byName[f.name] = f
+	}
+
+	e := &Exporter[T]{}
+	schema := make([]Field, len(columns))
+
+	for i, c := range columns {
+		f, ok := byName[c.Field]
+		if !ok {
+			return nil, fmt.Errorf("export: unknown field %q", c.Field)
+		}
+
+		name := c.Name
+		if name == "" {
+			name = c.Field
+		}
+
+		schema[i] = Field{Name: name, Type: f.typ}
+		e.values = append(e.values, f.value)
+	}
+
+	enc, err := format(w, schema)
+	if err != nil {
+		return nil, err
+	}
+	e.enc = enc
+
+	return e, nil
+}
+
+// Write encodes the records.
+func (e *Exporter[T]) Write(records ...T) error {
+	for _, record := range records {
+		row := make([]interface{}, len(e.values))
+		for i, value := range e.values {
+			row[i] = value(record)
+		}
+
+		if err := e.enc.Encode(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close flushes the encoder. It doesn't close the underlying writer.
+func (e *Exporter[T]) Close() error {
+	return e.enc.Close()
+}
+
+// NewBusinessExporter creates an exporter of businesses, as returned by searches, with the given columns or every BusinessFields when nil.
+func NewBusinessExporter(w io.Writer, format Format, columns []Column) (*Exporter[yelp.Business], error) {
+	return newExporter(w, format, businessFields, columns)
+}
+
+// NewBusinessDetailsExporter creates an exporter of business details with the given columns or every BusinessDetailsFields when nil.
+func NewBusinessDetailsExporter(w io.Writer, format Format, columns []Column) (*Exporter[yelp.BusinessDetailsRes], error) {
+	return newExporter(w, format, businessDetailsFields, columns)
+}
+
+// NewReviewExporter creates an exporter of reviews with the given columns or every ReviewFields when nil.
+func NewReviewExporter(w io.Writer, format Format, columns []Column) (*Exporter[yelp.Review], error) {
+	return newExporter(w, format, reviewFields, columns)
+}
+
+// ExportSearch writes every business returned by the iterator as it fetches pages, so whole searches are exported
+// without holding them in memory. The writer is a business Exporter or a GeoJSONWriter. It returns the number of businesses written.
+func ExportSearch(it *yelp.BusinessSearchIterator, e BusinessWriter) (int, error) {
+	n := 0
+
+	for it.Next() {
+		if err := e.Write(it.Business()); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, it.Err()
+}
@@ -0,0 +1,126 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/export"
+	"github.com/naguigui/yelp-fusion/yelp/yelptest"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+var BUSINESS = yelp.Business{
+	ID: "gary-danko", Name: "Gary Danko, SF", Rating: 4.5, ReviewCount: 4525, Price: "$$$$",
+	Categories:  []yelp.Category{{Alias: "newamerican", Title: "American (New)"}, {Alias: "wine_bars", Title: "Wine Bars"}},
+	Coordinates: yelp.Coordinates{Latitude: 37.80587, Longitude: -122.42058},
+	Location:    yelp.Location{Address1: "800 N Point St", City: "San Francisco", State: "CA", ZipCode: "94109", Country: "US"},
+}
+
+func TestCSVFlattensConfiguredColumns(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	e, err := export.NewBusinessExporter(&buf, export.CSV, []export.Column{
+		{Field: "id"}, {Field: "name"}, {Field: "review_count", Name: "reviews"}, {Field: "location.city", Name: "city"},
+		{Field: "categories.alias"}, {Field: "coordinates.latitude"},
+	})
+	assert.NoError(t, err)
+
+	// Act
+	writeErr := e.Write(BUSINESS)
+	closeErr := e.Close()
+	records, readErr := csv.NewReader(&buf).ReadAll()
+
+	// Assert
+	assert.NoError(t, writeErr)
+	assert.NoError(t, closeErr)
+	assert.NoError(t, readErr)
+	assert.Equal(t, [][]string{
+		{"id", "name", "reviews", "city", "categories.alias", "coordinates.latitude"},
+		{"gary-danko", "Gary Danko, SF", "4525", "San Francisco", "newamerican,wine_bars", "37.80587"},
+	}, records)
+}
+
+func TestNDJSONWritesTypedValuesInColumnOrder(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	e, err := export.NewReviewExporter(&buf, export.NDJSON, []export.Column{{Field: "rating"}, {Field: "user.name", Name: "author"}, {Field: "text"}})
+	assert.NoError(t, err)
+
+	// Act
+	writeErr := e.Write(yelp.Review{Rating: 5, User: yelp.User{Name: "Ann"}, Text: "Great \"wine\""}, yelp.Review{Rating: 2, Text: "Meh"})
+	closeErr := e.Close()
+
+	// Assert
+	assert.NoError(t, writeErr)
+	assert.NoError(t, closeErr)
+	assert.Equal(t, "{\"rating\":5,\"author\":\"Ann\",\"text\":\"Great \\\"wine\\\"\"}\n{\"rating\":2,\"author\":\"\",\"text\":\"Meh\"}\n", buf.String())
+}
+
+func TestExporterDefaultsToEveryField(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	e, err := export.NewBusinessDetailsExporter(&buf, export.NDJSON, nil)
+	assert.NoError(t, err)
+
+	details := yelp.BusinessDetailsRes{ID: "gary-danko", IsClaimed: true}
+	details.Location.DisplayAddress = []string{"800 N Point St", "San Francisco, CA 94109"}
+
+	// Act
+	writeErr := e.Write(details)
+	closeErr := e.Close()
+
+	var row map[string]interface{}
+	jsonErr := json.Unmarshal(buf.Bytes(), &row)
+
+	// Assert
+	assert.NoError(t, writeErr)
+	assert.NoError(t, closeErr)
+	assert.NoError(t, jsonErr)
+	assert.Len(t, row, len(export.BusinessDetailsFields()))
+	assert.Equal(t, true, row["is_claimed"])
+	assert.Equal(t, "800 N Point St, San Francisco, CA 94109", row["location.display_address"])
+}
+
+func TestExporterRejectsUnknownField(t *testing.T) {
+	// Act
+	e, err := export.NewBusinessExporter(&bytes.Buffer{}, export.CSV, []export.Column{{Field: "location.planet"}})
+
+	// Assert
+	assert.Nil(t, e)
+	assert.EqualError(t, err, "export: unknown field \"location.planet\"")
+}
+
+func TestExportSearchStreamsEveryPage(t *testing.T) {
+	// Arrange
+	server := yelptest.NewServer()
+	defer server.Close()
+
+	for i := 0; i < 120; i++ {
+		server.AddBusinesses(yelp.BusinessDetailsRes{
+			ID: fmt.Sprintf("business-%03d", i), Name: "Business", ReviewCount: i,
+			Location: yelp.LocationBusinessDetails{Location: yelp.Location{City: "Toronto", Country: "CA"}},
+		})
+	}
+
+	var buf bytes.Buffer
+	e, err := export.NewBusinessExporter(&buf, export.CSV, []export.Column{{Field: "id"}})
+	assert.NoError(t, err)
+
+	it := server.Client(nil).NewBusinessSearchIterator(context.Background(), yelp.BusinessSearchReq{Location: "Toronto", Limit: 50}, nil)
+
+	// Act
+	n, exportErr := export.ExportSearch(it, e)
+	closeErr := e.Close()
+
+	// Assert
+	assert.NoError(t, exportErr)
+	assert.NoError(t, closeErr)
+	assert.Equal(t, 120, n)
+	assert.Equal(t, 121, strings.Count(buf.String(), "\n"))
+	assert.Len(t, server.Requests(), 3)
+}
@@ -0,0 +1,146 @@
+package export
+
+import (
+	"github.com/naguigui/yelp-fusion/yelp"
+	"strconv"
+	"strings"
+)
+
+// float widens a float32 of the API to the float64 with the same shortest decimal form, so 37.80587 isn't exported as 37.805870056152344.
+func float(f float32) float64 {
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return v
+}
+
+// categoryAliases joins the aliases of the categories with commas.
+func categoryAliases(categories []yelp.Category) string {
+	aliases := make([]string, len(categories))
+	for i, category := range categories {
+		aliases[i] = category.Alias
+	}
+
+	return strings.Join(aliases, ",")
+}
+
+// categoryTitles joins the titles of the categories with commas.
+func categoryTitles(categories []yelp.Category) string {
+	titles := make([]string, len(categories))
+	for i, category := range categories {
+		titles[i] = category.Title
+	}
+
+	return strings.Join(titles, ",")
+}
+
+// locationFields flattens a Location into "location." fields of T.
+func locationFields[T any](location func(record T) yelp.Location) []field[T] {
+	return []field[T]{
+		{"location.address1", TYPE_STRING, func(r T) interface{} { return location(r).Address1 }},
+		{"location.address2", TYPE_STRING, func(r T) interface{} { return location(r).Address2 }},
+		{"location.address3", TYPE_STRING, func(r T) interface{} { return location(r).Address3 }},
+		{"location.city", TYPE_STRING, func(r T) interface{} { return location(r).City }},
+		{"location.state", TYPE_STRING, func(r T) interface{} { return location(r).State }},
+		{"location.zip_code", TYPE_STRING, func(r T) interface{} { return location(r).ZipCode }},
+		{"location.country", TYPE_STRING, func(r T) interface{} { return location(r).Country }},
+	}
+}
+
+// businessFields are the fields of businesses returned by searches, in their default column order.
+var businessFields = concat(
+	[]field[yelp.Business]{
+		{"id", TYPE_STRING, func(b yelp.Business) interface{} { return b.ID }},
+		{"alias", TYPE_STRING, func(b yelp.Business) interface{} { return b.Alias }},
+		{"name", TYPE_STRING, func(b yelp.Business) interface{} { return b.Name }},
+		{"rating", TYPE_FLOAT, func(b yelp.Business) interface{} { return float(b.Rating) }},
+		{"review_count", TYPE_INT, func(b yelp.Business) interface{} { return int64(b.ReviewCount) }},
+		{"price", TYPE_STRING, func(b yelp.Business) interface{} { return b.Price }},
+		{"phone", TYPE_STRING, func(b yelp.Business) interface{} { return b.Phone }},
+		{"is_closed", TYPE_BOOL, func(b yelp.Business) interface{} { return b.IsClosed }},
+		{"url", TYPE_STRING, func(b yelp.Business) interface{} { return b.URL }},
+		{"image_url", TYPE_STRING, func(b yelp.Business) interface{} { return b.ImageURL }},
+		{"distance", TYPE_FLOAT, func(b yelp.Business) interface{} { return float(b.Distance) }},
+		{"categories.alias", TYPE_STRING, func(b yelp.Business) interface{} { return categoryAliases(b.Categories) }},
+		{"categories.title", TYPE_STRING, func(b yelp.Business) interface{} { return categoryTitles(b.Categories) }},
+		{"coordinates.latitude", TYPE_FLOAT, func(b yelp.Business) interface{} { return float(b.Coordinates.Latitude) }},
+		{"coordinates.longitude", TYPE_FLOAT, func(b yelp.Business) interface{} { return float(b.Coordinates.Longitude) }},
+		{"transactions", TYPE_STRING, func(b yelp.Business) interface{} { return strings.Join(b.Transactions, ",") }},
+	},
+	locationFields(func(b yelp.Business) yelp.Location { return b.Location }),
+)
+
+// businessDetailsFields are the fields of business details, in their default column order.
+var businessDetailsFields = concat(
+	[]field[yelp.BusinessDetailsRes]{
+		{"id", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return b.ID }},
+		{"alias", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return b.Alias }},
+		{"name", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return b.Name }},
+		{"rating", TYPE_FLOAT, func(b yelp.BusinessDetailsRes) interface{} { return float(b.Rating) }},
+		{"review_count", TYPE_INT, func(b yelp.BusinessDetailsRes) interface{} { return int64(b.ReviewCount) }},
+		{"price", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return b.Price }},
+		{"phone", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return b.Phone }},
+		{"display_phone", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return b.DisplayPhone }},
+		{"is_claimed", TYPE_BOOL, func(b yelp.BusinessDetailsRes) interface{} { return b.IsClaimed }},
+		{"is_closed", TYPE_BOOL, func(b yelp.BusinessDetailsRes) interface{} { return b.IsClosed }},
+		{"url", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return b.URL }},
+		{"image_url", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return b.ImageURL }},
+		{"categories.alias", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return categoryAliases(b.Categories) }},
+		{"categories.title", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return categoryTitles(b.Categories) }},
+		{"coordinates.latitude", TYPE_FLOAT, func(b yelp.BusinessDetailsRes) interface{} { return float(b.Coordinates.Latitude) }},
+		{"coordinates.longitude", TYPE_FLOAT, func(b yelp.BusinessDetailsRes) interface{} { return float(b.Coordinates.Longitude) }},
+		{"transactions", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return strings.Join(b.Transactions, ",") }},
+		{"photos", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return strings.Join(b.Photos, ",") }},
+	},
+	locationFields(func(b yelp.BusinessDetailsRes) yelp.Location { return b.Location.Location }),
+	[]field[yelp.BusinessDetailsRes]{
+		{"location.display_address", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return strings.Join(b.Location.DisplayAddress, ", ") }},
+		{"location.cross_streets", TYPE_STRING, func(b yelp.BusinessDetailsRes) interface{} { return b.Location.CrossStreets }},
+	},
+)
+
+// reviewFields are the fields of reviews, in their default column order.
+var reviewFields = []field[yelp.Review]{
+	{"id", TYPE_STRING, func(r yelp.Review) interface{} { return r.ID }},
+	{"rating", TYPE_INT, func(r yelp.Review) interface{} { return int64(r.Rating) }},
+	{"text", TYPE_STRING, func(r yelp.Review) interface{} { return r.Text }},
+	{"time_created", TYPE_STRING, func(r yelp.Review) interface{} { return r.TimeCreated }},
+	{"url", TYPE_STRING, func(r yelp.Review) interface{} { return r.URL }},
+	{"user.id", TYPE_STRING, func(r yelp.Review) interface{} { return r.User.ID }},
+	{"user.name", TYPE_STRING, func(r yelp.Review) interface{} { return r.User.Name }},
+	{"user.profile_url", TYPE_STRING, func(r yelp.Review) interface{} { return r.User.ProfileURL }},
+	{"user.image_url", TYPE_STRING, func(r yelp.Review) interface{} { return r.User.ImageURL }},
+}
+
+// concat joins lists of fields.
+func concat[T any](lists ...[]field[T]) []field[T] {
+	var fields []field[T]
+	for _, list := range lists {
+		fields = append(fields, list...)
+	}
+
+	return fields
+}
+
+// names returns the names of the fields.
+func names[T any](fields []field[T]) []string {
+	n := make([]string, len(fields))
+	for i, f := range fields {
+		n[i] = f.name
+	}
+
+	return n
+}
+
+// BusinessFields returns the names of the fields of businesses returned by searches, in their default column order.
+func BusinessFields() []string {
+	return names(businessFields)
+}
+
+// BusinessDetailsFields returns the names of the fields of business details, in their default column order.
+func BusinessDetailsFields() []string {
+	return names(businessDetailsFields)
+}
+
+// ReviewFields returns the names of the fields of reviews, in their default column order.
+func ReviewFields() []string {
+	return names(reviewFields)
+}
@@ -0,0 +1,173 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"io"
+)
+
+// GeoJSON object types
+const (
+	GEOJSON_FEATURE_COLLECTION = "FeatureCollection"
+	GEOJSON_FEATURE            = "Feature"
+	GEOJSON_POINT              = "Point"
+)
+
+// Point is a GeoJSON Point geometry.
+type Point struct {
+	Type        string     `json:"type"`        // Always Point
+	Coordinates [2]float64 `json:"coordinates"` // Longitude and latitude, in that order as required by GeoJSON
+}
+
+// BusinessProperties are the properties of the GeoJSON feature of a business.
+type BusinessProperties struct {
+	Name       string   `json:"name"`       // Name of the business
+	Rating     float64  `json:"rating"`     // Rating of the business
+	Price      string   `json:"price"`      // Price level of the business, one of $, $$, $$$ and $$$$
+	Categories []string `json:"categories"` // Aliases of the categories of the business
+	URL        string   `json:"url"`        // URL of the business page on Yelp
+}
+
+// Feature is the GeoJSON feature of a business.
+type Feature struct {
+	Type       string             `json:"type"`       // Always Feature
+	ID         string             `json:"id"`         // Yelp ID of the business
+	Geometry   *Point             `json:"geometry"`   // Location of the business. Null when the business has no coordinates
+	Properties BusinessProperties `json:"properties"` // Properties of the business
+}
+
+// Metadata describes the search a feature collection was built from. It's written as the "metadata" foreign member of the collection.
+type Metadata struct {
+	RegionCenter *Point `json:"region_center,omitempty"` // Optional. Center of the suggested map area of the search
+	Total        int    `json:"total,omitempty"`         // Optional. Total number of businesses matching the search
+}
+
+// FeatureCollection is a GeoJSON FeatureCollection of businesses.
+type FeatureCollection struct {
+	Type     string    `json:"type"`               // Always FeatureCollection
+	Features []Feature `json:"features"`           // Features of the businesses
+	Metadata *Metadata `json:"metadata,omitempty"` // Optional. Metadata of the search
+}
+
+// GeoJSONOptions configures the GeoJSON feature collections of searches.
+type GeoJSONOptions struct {
+	Region bool // Optional. Add the region center and total of the search as metadata
+}
+
+// BusinessWriter is implemented by the exporters and the GeoJSONWriter consuming businesses, for example from ExportSearch.
+type BusinessWriter interface {
+	Write(businesses ...yelp.Business) error
+}
+
+// newPoint creates the point of the coordinates, or nil for the zero coordinates of businesses without location.
+func newPoint(latitude float32, longitude float32) *Point {
+	if latitude == 0 && longitude == 0 {
+		return nil
+	}
+
+	return &Point{Type: GEOJSON_POINT, Coordinates: [2]float64{float(longitude), float(latitude)}}
+}
+
+// NewFeature creates the GeoJSON feature of a business.
+func NewFeature(b yelp.Business) Feature {
+	categories := make([]string, len(b.Categories))
+	for i, category := range b.Categories {
+		categories[i] = category.Alias
+	}
+
+	return Feature{
+		Type:     GEOJSON_FEATURE,
+		ID:       b.ID,
+		Geometry: newPoint(b.Coordinates.Latitude, b.Coordinates.Longitude),
+		Properties: BusinessProperties{
+			Name:       b.Name,
+			Rating:     float(b.Rating),
+			Price:      b.Price,
+			Categories: categories,
+			URL:        b.URL,
+		},
+	}
+}
+
+// searchMetadata returns the metadata of a search, or nil unless GeoJSONOptions.Region is set.
+func searchMetadata(res *yelp.BusinessSearchRes, options *GeoJSONOptions) *Metadata {
+	if options == nil || !options.Region {
+		return nil
+	}
+
+	return &Metadata{RegionCenter: newPoint(res.Region.Center.Latitude, res.Region.Center.Longitude), Total: res.Total}
+}
+
+// NewFeatureCollection creates the GeoJSON feature collection of the businesses of a search. Options may be nil.
+func NewFeatureCollection(res *yelp.BusinessSearchRes, options *GeoJSONOptions) *FeatureCollection {
+	fc := &FeatureCollection{Type: GEOJSON_FEATURE_COLLECTION, Features: make([]Feature, len(res.Businesses))}
+
+	for i, b := range res.Businesses {
+		fc.Features[i] = NewFeature(b)
+	}
+	fc.Metadata = searchMetadata(res, options)
+
+	return fc
+}
+
+// GeoJSONWriter streams businesses as the features of a GeoJSON feature collection, for example from ExportSearch,
+// writing each feature as it comes. The collection is complete once Close is called.
+type GeoJSONWriter struct {
+	w        *bufio.Writer
+	metadata *Metadata
+	count    int
+	err      error
+}
+
+// NewGeoJSONWriter creates a writer of a feature collection with the given metadata, which may be nil.
+func NewGeoJSONWriter(w io.Writer, metadata *Metadata) *GeoJSONWriter {
+	gw := &GeoJSONWriter{w: bufio.NewWriter(w), metadata: metadata}
+	_, gw.err = gw.w.WriteString(`{"type":"` + GEOJSON_FEATURE_COLLECTION + `","features":[`)
+
+	return gw
+}
+
+// Write writes the features of the businesses.
+func (gw *GeoJSONWriter) Write(businesses ...yelp.Business) error {
+	for _, b := range businesses {
+		if gw.err != nil {
+			return gw.err
+		}
+
+		data, err := json.Marshal(NewFeature(b))
+		if err != nil {
+			return err
+		}
+
+		if gw.count > 0 {
+			gw.w.WriteByte(',')
+		}
+		_, gw.err = gw.w.Write(data)
+		gw.count++
+	}
+
+	return gw.err
+}
+
+// Close ends the feature collection with its metadata and flushes it. It doesn't close the underlying writer.
+func (gw *GeoJSONWriter) Close() error {
+	if gw.err != nil {
+		return gw.err
+	}
+
+	gw.w.WriteByte(']')
+
+	if gw.metadata != nil {
+		data, err := json.Marshal(gw.metadata)
+		if err != nil {
+			return err
+		}
+		gw.w.WriteString(`,"metadata":`)
+		gw.w.Write(data)
+	}
+
+	gw.w.WriteString("}\n")
+
+	return gw.w.Flush()
+}
@@ -0,0 +1,97 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/export"
+	"github.com/naguigui/yelp-fusion/yelp/yelptest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const BUSINESS_FEATURE = `{
+	"type": "Feature",
+	"id": "gary-danko",
+	"geometry": {"type": "Point", "coordinates": [-122.42058, 37.80587]},
+	"properties": {"name": "Gary Danko, SF", "rating": 4.5, "price": "$$$$", "categories": ["newamerican", "wine_bars"], "url": ""}
+}`
+
+func TestNewFeatureCollection(t *testing.T) {
+	// Arrange
+	res := &yelp.BusinessSearchRes{
+		Businesses: []yelp.Business{BUSINESS, {ID: "nowhere", Name: "Nowhere"}},
+		Total:      2,
+		Region:     yelp.Region{Center: yelp.Center{Latitude: 37.8, Longitude: -122.4}},
+	}
+
+	// Act
+	plain := export.NewFeatureCollection(res, nil)
+	withRegion := export.NewFeatureCollection(res, &export.GeoJSONOptions{Region: true})
+
+	feature, err := json.Marshal(plain.Features[0])
+	data, _ := json.Marshal(plain)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "FeatureCollection", plain.Type)
+	assert.Len(t, plain.Features, 2)
+	assert.JSONEq(t, BUSINESS_FEATURE, string(feature))
+	assert.Nil(t, plain.Features[1].Geometry)
+	assert.NotContains(t, string(data), "metadata")
+	assert.Equal(t, [2]float64{-122.4, 37.8}, withRegion.Metadata.RegionCenter.Coordinates)
+	assert.Equal(t, 2, withRegion.Metadata.Total)
+}
+
+func TestGeoJSONWriterStreamsSearch(t *testing.T) {
+	// Arrange
+	server := yelptest.NewServer()
+	defer server.Close()
+
+	server.AddBusinesses(
+		yelp.BusinessDetailsRes{
+			ID: "gary-danko", Name: "Gary Danko",
+			Location:    yelp.LocationBusinessDetails{Location: yelp.Location{City: "San Francisco"}},
+			Coordinates: yelp.Coordinates{Latitude: 37.80587, Longitude: -122.42058},
+		},
+		yelp.BusinessDetailsRes{
+			ID: "tartine", Name: "Tartine Bakery",
+			Location:    yelp.LocationBusinessDetails{Location: yelp.Location{City: "San Francisco"}},
+			Coordinates: yelp.Coordinates{Latitude: 37.76139, Longitude: -122.42415},
+		},
+	)
+
+	var buf bytes.Buffer
+	gw := export.NewGeoJSONWriter(&buf, &export.Metadata{Total: 2})
+	it := server.Client(nil).NewBusinessSearchIterator(context.Background(), yelp.BusinessSearchReq{Location: "San Francisco", Limit: 1}, nil)
+
+	// Act
+	n, exportErr := export.ExportSearch(it, gw)
+	closeErr := gw.Close()
+
+	var fc export.FeatureCollection
+	jsonErr := json.Unmarshal(buf.Bytes(), &fc)
+
+	// Assert
+	assert.NoError(t, exportErr)
+	assert.NoError(t, closeErr)
+	assert.NoError(t, jsonErr)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "FeatureCollection", fc.Type)
+	assert.Len(t, fc.Features, 2)
+	assert.Equal(t, "Point", fc.Features[0].Geometry.Type)
+	assert.Equal(t, 2, fc.Metadata.Total)
+}
+
+func TestGeoJSONWriterWritesEmptyCollection(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := export.NewGeoJSONWriter(&buf, nil).Close()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "FeatureCollection", "features": []}`, buf.String())
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// ndjsonEncoder writes rows as JSON objects, one per line, keyed by column name.
+type ndjsonEncoder struct {
+	w    *bufio.Writer
+	keys [][]byte
+}
+
+// NDJSON is the Format writing rows as newline delimited JSON objects, keyed by column name in column order.
+func NDJSON(w io.Writer, fields []Field) (Encoder, error) {
+	e := &ndjsonEncoder{w: bufio.NewWriter(w), keys: make([][]byte, len(fields))}
+
+	for i, f := range fields {
+		key, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		e.keys[i] = key
+	}
+
+	return e, nil
+}
+
+// Encode writes a row as a JSON object on its own line. Lines are buffered until Close.
+func (e *ndjsonEncoder) Encode(row []interface{}) error {
+	e.w.WriteByte('{')
+
+	for i, v := range row {
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			e.w.WriteByte(',')
+		}
+		e.w.Write(e.keys[i])
+		e.w.WriteByte(':')
+		e.w.Write(value)
+	}
+
+	_, err := e.w.WriteString("}\n")
+	return err
+}
+
+// Close flushes the buffered lines.
+func (e *ndjsonEncoder) Close() error {
+	return e.w.Flush()
+}
@@ -0,0 +1,66 @@
+// Package parquet provides the Parquet export.Format, for loading exported Yelp data into data lake tables.
+//
+//	e, err := export.NewBusinessExporter(file, parquet.Format, nil)
+package parquet
+
+import (
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp/export"
+	"github.com/xitongsys/parquet-go/writer"
+	"io"
+	"strings"
+)
+
+// ROW_GROUP_SIZE is the size in bytes of the row groups, after which buffered rows are written out
+const ROW_GROUP_SIZE = 16 * 1024 * 1024
+
+// types maps field types onto Parquet physical and converted types.
+var types = map[export.FieldType]string{
+	export.TYPE_STRING: "type=BYTE_ARRAY, convertedtype=UTF8",
+	export.TYPE_INT:    "type=INT64",
+	export.TYPE_FLOAT:  "type=DOUBLE",
+	export.TYPE_BOOL:   "type=BOOLEAN",
+}
+
+// encoder writes rows to a Parquet file.
+type encoder struct {
+	w *writer.CSVWriter
+}
+
+// ColumnName returns the Parquet column name of a field. Dots of flattened fields are replaced with underscores,
+// since Parquet reads them as nested groups, for example "location.city" becomes "location_city".
+func ColumnName(field string) string {
+	return strings.ReplaceAll(field, ".", "_")
+}
+
+// Format is the export.Format writing rows to a Parquet file with one required column per field.
+// Rows are buffered in row groups, and the file footer is written by Close.
+func Format(w io.Writer, fields []export.Field) (export.Encoder, error) {
+	schema := make([]string, len(fields))
+
+	for i, f := range fields {
+		typ, ok := types[f.Type]
+		if !ok {
+			return nil, fmt.Errorf("parquet: unsupported type of field %q", f.Name)
+		}
+		schema[i] = fmt.Sprintf("name=%s, %s, repetitiontype=REQUIRED", ColumnName(f.Name), typ)
+	}
+
+	pw, err := writer.NewCSVWriterFromWriter(schema, w, 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.RowGroupSize = ROW_GROUP_SIZE
+
+	return &encoder{w: pw}, nil
+}
+
+// Encode buffers a row.
+func (e *encoder) Encode(row []interface{}) error {
+	return e.w.Write(row)
+}
+
+// Close writes the buffered rows and the file footer.
+func (e *encoder) Close() error {
+	return e.w.WriteStop()
+}
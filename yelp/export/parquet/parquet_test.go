@@ -0,0 +1,88 @@
+package parquet_test
+
+import (
+	"bytes"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/export"
+	"github.com/naguigui/yelp-fusion/yelp/export/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	format "github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"reflect"
+	"testing"
+)
+
+var BUSINESSES = []yelp.Business{
+	{
+		ID: "gary-danko", Name: "Gary Danko", Rating: 4.5, ReviewCount: 4525, IsClosed: false,
+		Location: yelp.Location{City: "San Francisco"},
+	},
+	{
+		ID: "tartine", Name: "Tartine Bakery", Rating: 4, ReviewCount: 8000, IsClosed: true,
+		Location: yelp.Location{City: "San Francisco"},
+	},
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	e, err := export.NewBusinessExporter(&buf, parquet.Format, []export.Column{
+		{Field: "id"}, {Field: "rating"}, {Field: "review_count"}, {Field: "is_closed"}, {Field: "location.city"},
+	})
+	assert.NoError(t, err)
+
+	// Act
+	writeErr := e.Write(BUSINESSES...)
+	closeErr := e.Close()
+
+	file, fileErr := buffer.NewBufferFile(buf.Bytes())
+	assert.NoError(t, fileErr)
+	pr, readerErr := reader.NewParquetReader(file, nil, 1)
+	assert.NoError(t, readerErr)
+	defer pr.ReadStop()
+
+	rows, readErr := pr.ReadByNumber(int(pr.GetNumRows()))
+
+	// Assert
+	assert.NoError(t, writeErr)
+	assert.NoError(t, closeErr)
+	assert.NoError(t, readErr)
+
+	type column struct {
+		name      string
+		typ       format.Type
+		converted *format.ConvertedType
+	}
+	utf8 := format.ConvertedType_UTF8
+
+	var columns []column
+	for i, element := range pr.Footer.Schema[1:] {
+		assert.Equal(t, format.FieldRepetitionType_REQUIRED, element.GetRepetitionType())
+		columns = append(columns, column{pr.SchemaHandler.GetExName(i + 1), element.GetType(), element.ConvertedType})
+	}
+	assert.Equal(t, []column{
+		{"id", format.Type_BYTE_ARRAY, &utf8},
+		{"rating", format.Type_DOUBLE, nil},
+		{"review_count", format.Type_INT64, nil},
+		{"is_closed", format.Type_BOOLEAN, nil},
+		{"location_city", format.Type_BYTE_ARRAY, &utf8},
+	}, columns)
+
+	assert.Equal(t, int64(2), pr.GetNumRows())
+	assert.Len(t, rows, 2)
+
+	var values [][]interface{}
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		fields := make([]interface{}, v.NumField())
+		for i := range fields {
+			fields[i] = v.Field(i).Interface()
+		}
+		values = append(values, fields)
+	}
+	assert.Equal(t, [][]interface{}{
+		{"gary-danko", 4.5, int64(4525), false, "San Francisco"},
+		{"tartine", 4.0, int64(8000), true, "San Francisco"},
+	}, values)
+}
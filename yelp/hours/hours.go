@@ -0,0 +1,344 @@
+// Package hours interprets the opening hours of businesses returned by Business Details, applying their special hours.
+//
+//	schedule, err := hours.FromBusiness(business)
+//	open := schedule.IsOpenAt(time.Now(), loc)
+//	next, ok := schedule.NextOpen(time.Now(), loc)
+//
+// Yelp gives hours in the local time of the business without its time zone, so every evaluation takes the location
+// of the business. Times are compared on the wall clock of that location, so slots follow daylight saving time changes.
+package hours
+
+import (
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HOURS_TYPE_REGULAR = "REGULAR"      // Type of the regular opening hours, the only type Yelp returns
+	DATE_LAYOUT        = "2006-01-02"   // Layout of the dates of special hours
+	DAY                = 24 * time.Hour // Length of a day on the wall clock, ignoring daylight saving time changes
+)
+
+// dayNames are the abbreviated names of the days of the week as indexed by Yelp, from Monday to Sunday.
+var dayNames = [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// Slot is a time range a business is open on a day.
+type Slot struct {
+	Start time.Duration // Time of day the business opens, from midnight
+	End   time.Duration // Time the business closes, from midnight of the day it opened. Over 24 hours for overnight slots
+}
+
+// Overnight reports whether the slot ends on the next day.
+func (s Slot) Overnight() bool {
+	return s.End > DAY
+}
+
+// formatClock formats a time of day as 15:04.
+func formatClock(d time.Duration) string {
+	d %= DAY
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// String formats the slot like 18:00-02:00, marking overnight slots with a trailing "+1".
+func (s Slot) String() string {
+	if s.End == s.Start+DAY && s.Start == 0 {
+		return "00:00-24:00"
+	}
+
+	str := formatClock(s.Start) + "-" + formatClock(s.End)
+	if s.Overnight() {
+		str += "+1"
+	}
+
+	return str
+}
+
+// parseClock parses a time of day in 24-hour clock notation, like 2130 for 9:30 PM.
+func parseClock(v string) (time.Duration, error) {
+	if len(v) != 4 {
+		return 0, fmt.Errorf("hours: invalid time %q, expected HHMM", v)
+	}
+
+	hh, hhErr := strconv.Atoi(v[:2])
+	mm, mmErr := strconv.Atoi(v[2:])
+	if hhErr != nil || mmErr != nil || hh > 24 || mm > 59 || (hh == 24 && mm > 0) {
+		return 0, fmt.Errorf("hours: invalid time %q, expected HHMM", v)
+	}
+
+	return time.Duration(hh)*time.Hour + time.Duration(mm)*time.Minute, nil
+}
+
+// newSlot parses a slot. Slots ending at or before their start, or flagged overnight, end on the next day.
+func newSlot(start string, end string, overnight bool) (Slot, error) {
+	var s Slot
+	var err error
+
+	if s.Start, err = parseClock(start); err != nil {
+		return s, err
+	}
+	if s.End, err = parseClock(end); err != nil {
+		return s, err
+	}
+
+	if s.End <= s.Start || (overnight && s.End < DAY) {
+		s.End += DAY
+	}
+
+	return s, nil
+}
+
+// Schedule is the weekly opening hours of a business with its special hours.
+type Schedule struct {
+	regular [7][]Slot         // Slots of each day of the week, from Monday to Sunday
+	special map[string][]Slot // Slots of dates with special hours, empty for closed dates
+	last    time.Time         // Latest date with special hours
+}
+
+// New creates the schedule of the regular hours and special hours of a business. Hours of other types than REGULAR are ignored.
+func New(hours []yelp.Hours, special []yelp.SpecialHours) (*Schedule, error) {
+	s := &Schedule{special: make(map[string][]Slot)}
+
+	for _, h := range hours {
+		if h.HoursType != "" && h.HoursType != HOURS_TYPE_REGULAR {
+			continue
+		}
+
+		for _, o := range h.Open {
+			if o.Day < 0 || o.Day > 6 {
+				return nil, fmt.Errorf("hours: invalid day %d, expected 0 to 6", o.Day)
+			}
+
+			slot, err := newSlot(o.Start, o.End, o.IsOvernight)
+			if err != nil {
+				return nil, err
+			}
+			s.regular[o.Day] = append(s.regular[o.Day], slot)
+		}
+	}
+
+	for _, sh := range special {
+		date, err := time.Parse(DATE_LAYOUT, sh.Date)
+		if err != nil {
+			return nil, fmt.Errorf("hours: invalid special hours date %q, expected YYYY-MM-DD", sh.Date)
+		}
+		if date.After(s.last) {
+			s.last = date
+		}
+
+		slots := s.special[sh.Date]
+		if slots == nil {
+			slots = []Slot{}
+		}
+
+		if !sh.IsClosed {
+			slot, err := newSlot(sh.Start, sh.End, sh.IsOvernight)
+			if err != nil {
+				return nil, err
+			}
+			slots = append(slots, slot)
+		}
+
+		s.special[sh.Date] = slots
+	}
+
+	for day := range s.regular {
+		sortSlots(s.regular[day])
+	}
+	for date := range s.special {
+		sortSlots(s.special[date])
+	}
+
+	return s, nil
+}
+
+// FromBusiness creates the schedule of a business from its details.
+func FromBusiness(b yelp.BusinessDetailsRes) (*Schedule, error) {
+	return New(b.Hours, b.SpecialHours)
+}
+
+// sortSlots sorts slots by start time.
+func sortSlots(slots []Slot) {
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Start < slots[j].Start })
+}
+
+// weekday returns the Yelp index of the day of the week of t, from 0 for Monday to 6 for Sunday.
+func weekday(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// Slots returns the slots of the calendar date of t in its location: its special hours when it has some, else its regular hours.
+// Slots belong to the day they open on, so an overnight slot of the previous day isn't returned.
+func (s *Schedule) Slots(t time.Time) []Slot {
+	if slots, ok := s.special[t.Format(DATE_LAYOUT)]; ok {
+		return slots
+	}
+
+	return s.regular[weekday(t)]
+}
+
+// span is an opening between two instants.
+type span struct {
+	start time.Time
+	end   time.Time
+}
+
+// spans returns the openings of the days from the day before t to days after it, in the location of t. Adjacent and overlapping slots are merged.
+func (s *Schedule) spans(t time.Time, days int) []span {
+	var spans []span
+
+	for i := -1; i <= days; i++ {
+		date := time.Date(t.Year(), t.Month(), t.Day()+i, 0, 0, 0, 0, t.Location())
+
+		for _, slot := range s.Slots(date) {
+			spans = append(spans, span{
+				start: time.Date(date.Year(), date.Month(), date.Day(), 0, int(slot.Start/time.Minute), 0, 0, date.Location()),
+				end:   time.Date(date.Year(), date.Month(), date.Day(), 0, int(slot.End/time.Minute), 0, 0, date.Location()),
+			})
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+	var merged []span
+	for _, sp := range spans {
+		if n := len(merged); n > 0 && !sp.start.After(merged[n-1].end) {
+			if sp.end.After(merged[n-1].end) {
+				merged[n-1].end = sp.end
+			}
+			continue
+		}
+		merged = append(merged, sp)
+	}
+
+	return merged
+}
+
+// horizon returns the number of days after t searched for openings: a week, or up to the last date with special hours.
+func (s *Schedule) horizon(t time.Time) int {
+	days := 8
+
+	last := time.Date(s.last.Year(), s.last.Month(), s.last.Day(), 0, 0, 0, 0, t.Location())
+	if d := int(last.Sub(t)/DAY) + 2; d > days {
+		days = d
+	}
+
+	return days
+}
+
+// in returns t in loc, or unchanged when loc is nil.
+func in(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+
+	return t.In(loc)
+}
+
+// IsOpenAt reports whether the business is open at t. The location is the time zone of the business, or the location of t when nil.
+func (s *Schedule) IsOpenAt(t time.Time, loc *time.Location) bool {
+	t = in(t, loc)
+
+	for _, sp := range s.spans(t, 0) {
+		if !t.Before(sp.start) && t.Before(sp.end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextOpen returns when the business opens next after t. When it's open at t, this is the opening after the current one.
+// It returns false when the business doesn't open in the following week or before its last special hours.
+func (s *Schedule) NextOpen(t time.Time, loc *time.Location) (time.Time, bool) {
+	t = in(t, loc)
+
+	for _, sp := range s.spans(t, s.horizon(t)) {
+		if sp.start.After(t) {
+			return sp.start, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// NextClose returns when the business closes next after t: the end of the current opening when it's open at t, else of the next one.
+// It returns false when the business doesn't open in the following week or never closes, like businesses open around the clock.
+func (s *Schedule) NextClose(t time.Time, loc *time.Location) (time.Time, bool) {
+	t = in(t, loc)
+	days := s.horizon(t)
+	spans := s.spans(t, days)
+
+	for i, sp := range spans {
+		if !sp.end.After(t) {
+			continue
+		}
+
+		if i == len(spans)-1 && !sp.end.Before(time.Date(t.Year(), t.Month(), t.Day()+days+1, 0, 0, 0, 0, t.Location())) {
+			return time.Time{}, false
+		}
+
+		return sp.end, true
+	}
+
+	return time.Time{}, false
+}
+
+// Day is the opening hours of a calendar date.
+type Day struct {
+	Date    time.Time // Midnight of the date
+	Slots   []Slot    // Slots the business opens on the date, empty when it's closed
+	Special bool      // Whether the slots are special hours overriding the regular hours
+}
+
+// String formats the day like "Fri 2024-05-03: 11:00-14:00, 18:00-02:00+1", marking special hours.
+func (d Day) String() string {
+	str := dayNames[weekday(d.Date)] + " " + d.Date.Format(DATE_LAYOUT) + ": " + formatSlots(d.Slots)
+	if d.Special {
+		str += " (special hours)"
+	}
+
+	return str
+}
+
+// formatSlots formats slots separated by commas, or "Closed" when there are none.
+func formatSlots(slots []Slot) string {
+	if len(slots) == 0 {
+		return "Closed"
+	}
+
+	parts := make([]string, len(slots))
+	for i, slot := range slots {
+		parts[i] = slot.String()
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Week returns the hours of the seven days starting on the date of t in loc, with special hours applied.
+func (s *Schedule) Week(t time.Time, loc *time.Location) []Day {
+	t = in(t, loc)
+	days := make([]Day, 7)
+
+	for i := range days {
+		date := time.Date(t.Year(), t.Month(), t.Day()+i, 0, 0, 0, 0, t.Location())
+		_, special := s.special[date.Format(DATE_LAYOUT)]
+		days[i] = Day{Date: date, Slots: s.Slots(date), Special: special}
+	}
+
+	return days
+}
+
+// String renders the regular hours of the week from Monday to Sunday, one day per line, like "Mon: 11:00-14:00, 17:00-22:00".
+func (s *Schedule) String() string {
+	var b strings.Builder
+
+	for day, slots := range s.regular {
+		fmt.Fprintf(&b, "%s: %s\n", dayNames[day], formatSlots(slots))
+	}
+
+	return b.String()
+}
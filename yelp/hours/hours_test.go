@@ -0,0 +1,161 @@
+package hours_test
+
+import (
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/hours"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// HOURS are lunch and dinner from Monday to Thursday, a late Friday running past midnight, and closed weekends.
+var HOURS = []yelp.Hours{{
+	HoursType: "REGULAR",
+	Open: []yelp.Open{
+		{Day: 0, Start: "1700", End: "2200"},
+		{Day: 0, Start: "1100", End: "1430"},
+		{Day: 1, Start: "1100", End: "1430"},
+		{Day: 1, Start: "1700", End: "2200"},
+		{Day: 2, Start: "1100", End: "2200"},
+		{Day: 3, Start: "1100", End: "2200"},
+		{Day: 4, Start: "1800", End: "0200", IsOvernight: true},
+	},
+}}
+
+var LA, _ = time.LoadLocation("America/Los_Angeles")
+
+// at returns a wall clock time in Los Angeles. May 6th 2024 is a Monday.
+func at(day int, hour int, minute int) time.Time {
+	return time.Date(2024, time.May, day, hour, minute, 0, 0, LA)
+}
+
+func schedule(t *testing.T, special ...yelp.SpecialHours) *hours.Schedule {
+	s, err := hours.New(HOURS, special)
+	assert.NoError(t, err)
+
+	return s
+}
+
+func TestIsOpenAtHandlesMultiSlotAndOvernightDays(t *testing.T) {
+	// Arrange
+	s := schedule(t)
+
+	// Assert
+	assert.True(t, s.IsOpenAt(at(6, 12, 0), LA))
+	assert.False(t, s.IsOpenAt(at(6, 15, 0), LA))
+	assert.True(t, s.IsOpenAt(at(6, 17, 0), LA))
+	assert.False(t, s.IsOpenAt(at(6, 22, 0), LA))
+	assert.True(t, s.IsOpenAt(at(10, 23, 30), LA))
+	assert.True(t, s.IsOpenAt(at(11, 1, 59), LA))
+	assert.False(t, s.IsOpenAt(at(11, 2, 0), LA))
+	assert.False(t, s.IsOpenAt(at(12, 1, 0), LA))
+}
+
+func TestIsOpenAtConvertsToTheBusinessLocation(t *testing.T) {
+	// Arrange
+	s := schedule(t)
+	utc := at(6, 21, 0).UTC()
+
+	// Assert
+	assert.True(t, s.IsOpenAt(utc, LA))
+	assert.False(t, s.IsOpenAt(utc, time.UTC))
+}
+
+func TestSpecialHoursOverrideRegularHours(t *testing.T) {
+	// Arrange
+	s := schedule(t,
+		yelp.SpecialHours{Date: "2024-05-07", IsClosed: true},
+		yelp.SpecialHours{Date: "2024-05-11", Start: "2000", End: "0300", IsOvernight: true},
+	)
+
+	// Assert
+	assert.False(t, s.IsOpenAt(at(7, 12, 0), LA))
+	assert.True(t, s.IsOpenAt(at(8, 12, 0), LA))
+	assert.True(t, s.IsOpenAt(at(11, 1, 0), LA), "the Friday overnight slot still runs into the special Saturday")
+	assert.True(t, s.IsOpenAt(at(11, 21, 0), LA))
+	assert.True(t, s.IsOpenAt(at(12, 2, 30), LA))
+	assert.False(t, s.IsOpenAt(at(12, 3, 0), LA))
+}
+
+func TestNextOpenAndNextClose(t *testing.T) {
+	// Arrange
+	s := schedule(t, yelp.SpecialHours{Date: "2024-05-07", IsClosed: true})
+
+	// Act
+	openAfterLunch, openAfterLunchOK := s.NextOpen(at(6, 15, 0), LA)
+	openWhileOpen, _ := s.NextOpen(at(6, 12, 0), LA)
+	openAfterClosedDay, _ := s.NextOpen(at(6, 23, 0), LA)
+	closeWhileOpen, closeWhileOpenOK := s.NextClose(at(10, 23, 0), LA)
+	closeWhileClosed, _ := s.NextClose(at(11, 12, 0), LA)
+
+	// Assert
+	assert.True(t, openAfterLunchOK)
+	assert.Equal(t, at(6, 17, 0), openAfterLunch)
+	assert.Equal(t, at(6, 17, 0), openWhileOpen)
+	assert.Equal(t, at(8, 11, 0), openAfterClosedDay)
+	assert.True(t, closeWhileOpenOK)
+	assert.Equal(t, at(11, 2, 0), closeWhileOpen)
+	assert.Equal(t, at(13, 14, 30), closeWhileClosed)
+}
+
+func TestNextOpenReachesDistantSpecialHours(t *testing.T) {
+	// Arrange
+	s, err := hours.New(nil, []yelp.SpecialHours{{Date: "2024-06-30", Start: "1000", End: "1600"}})
+
+	// Act
+	open, ok := s.NextOpen(at(6, 12, 0), LA)
+	_, laterOK := s.NextOpen(time.Date(2024, time.July, 1, 0, 0, 0, 0, LA), LA)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, time.June, 30, 10, 0, 0, 0, LA), open)
+	assert.False(t, laterOK)
+}
+
+func TestAroundTheClockBusinessNeverCloses(t *testing.T) {
+	// Arrange
+	var open []yelp.Open
+	for day := 0; day < 7; day++ {
+		open = append(open, yelp.Open{Day: day, Start: "0000", End: "0000", IsOvernight: true})
+	}
+	s, err := hours.New([]yelp.Hours{{HoursType: "REGULAR", Open: open}}, nil)
+
+	// Act
+	_, closeOK := s.NextClose(at(6, 12, 0), LA)
+	_, openOK := s.NextOpen(at(6, 12, 0), LA)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, s.IsOpenAt(at(9, 3, 0), LA))
+	assert.False(t, closeOK)
+	assert.False(t, openOK)
+	assert.Equal(t, "00:00-24:00", s.Slots(at(6, 0, 0))[0].String())
+}
+
+func TestScheduleRendering(t *testing.T) {
+	// Arrange
+	s := schedule(t, yelp.SpecialHours{Date: "2024-05-09", IsClosed: true})
+
+	// Act
+	week := s.Week(at(8, 12, 0), LA)
+
+	// Assert
+	assert.Equal(t, "Mon: 11:00-14:30, 17:00-22:00\nTue: 11:00-14:30, 17:00-22:00\nWed: 11:00-22:00\nThu: 11:00-22:00\nFri: 18:00-02:00+1\nSat: Closed\nSun: Closed\n", s.String())
+	assert.Len(t, week, 7)
+	assert.Equal(t, "Wed 2024-05-08: 11:00-22:00", week[0].String())
+	assert.Equal(t, "Thu 2024-05-09: Closed (special hours)", week[1].String())
+	assert.Equal(t, "Fri 2024-05-10: 18:00-02:00+1", week[2].String())
+}
+
+func TestNewRejectsInvalidHours(t *testing.T) {
+	// Act
+	_, timeErr := hours.New([]yelp.Hours{{Open: []yelp.Open{{Day: 0, Start: "25:00", End: "1200"}}}}, nil)
+	_, dayErr := hours.New([]yelp.Hours{{Open: []yelp.Open{{Day: 7, Start: "1000", End: "1200"}}}}, nil)
+	_, dateErr := hours.New(nil, []yelp.SpecialHours{{Date: "05/07/2024", IsClosed: true}})
+
+	// Assert
+	assert.EqualError(t, timeErr, "hours: invalid time \"25:00\", expected HHMM")
+	assert.EqualError(t, dayErr, "hours: invalid day 7, expected 0 to 6")
+	assert.EqualError(t, dateErr, "hours: invalid special hours date \"05/07/2024\", expected YYYY-MM-DD")
+}
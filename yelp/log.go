@@ -0,0 +1,121 @@
+package yelp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// REDACTED replaces secret values in log records
+const REDACTED = "REDACTED"
+
+// LogLevels sets the levels a Client logs calls at.
+type LogLevels struct {
+	Success slog.Leveler // Optional. Level of calls that succeeded, including cache hits. Defaults to slog.LevelDebug
+	Retry   slog.Leveler // Optional. Level of attempts that are about to be retried. Defaults to slog.LevelWarn
+	Failure slog.Leveler // Optional. Level of calls that failed. Defaults to slog.LevelError
+}
+
+// withDefaults returns a copy of the levels with missing values set to their defaults.
+func (l *LogLevels) withDefaults() LogLevels {
+	levels := LogLevels{}
+	if l != nil {
+		levels = *l
+	}
+
+	if levels.Success == nil {
+		levels.Success = slog.LevelDebug
+	}
+	if levels.Retry == nil {
+		levels.Retry = slog.LevelWarn
+	}
+	if levels.Failure == nil {
+		levels.Failure = slog.LevelError
+	}
+
+	return levels
+}
+
+// sensitiveParams are query parameters whose values are never logged.
+var sensitiveParams = map[string]bool{
+	"access_token":  true,
+	"api_key":       true,
+	"apikey":        true,
+	"authorization": true,
+	"key":           true,
+	"token":         true,
+}
+
+// sanitizeQuery returns the query of the URL with the values of sensitive parameters, and any value holding the API key, redacted.
+func (c *Client) sanitizeQuery(u *url.URL) string {
+	query := u.Query()
+
+	for key, values := range query {
+		for i, v := range values {
+			if sensitiveParams[strings.ToLower(key)] || (c.APIKey != "" && strings.Contains(v, c.APIKey)) {
+				values[i] = REDACTED
+			}
+		}
+	}
+
+	return query.Encode()
+}
+
+// requestAttrs returns the log attributes describing a request. Headers are never logged, so the Authorization bearer token can't leak.
+func (c *Client) requestAttrs(operation string, req *http.Request) []slog.Attr {
+	return []slog.Attr{
+		slog.String("operation", operation),
+		slog.String("method", req.Method),
+		slog.String("endpoint", req.URL.Path),
+		slog.String("query", c.sanitizeQuery(req.URL)),
+	}
+}
+
+// logCall logs the outcome of a call at the success or failure level.
+func (c *Client) logCall(ctx context.Context, operation string, req *http.Request, stats *callStats, elapsed time.Duration, err error) {
+	statusCode, errorCode := callStatus(err)
+
+	attrs := append(c.requestAttrs(operation, req),
+		slog.Int("status", statusCode),
+		slog.Duration("duration", elapsed),
+		slog.Int("attempts", int(stats.attempts.Load())),
+		slog.Bool("cache_hit", stats.cacheHit),
+	)
+
+	if err == nil {
+		c.logger.LogAttrs(ctx, c.logLevels.Success.Level(), "yelp request", attrs...)
+		return
+	}
+
+	if errorCode != "" {
+		attrs = append(attrs, slog.String("error_code", errorCode))
+	}
+	attrs = append(attrs, slog.String("error", err.Error()))
+
+	c.logger.LogAttrs(ctx, c.logLevels.Failure.Level(), "yelp request failed", attrs...)
+}
+
+// logRetry logs an attempt that failed with the status code or error and is retried after wait.
+func (c *Client) logRetry(ctx context.Context, req *http.Request, attempt int, wait time.Duration, statusCode int, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	operation, _ := OperationFromContext(ctx)
+
+	attrs := append(c.requestAttrs(operation, req),
+		slog.Int("attempt", attempt),
+		slog.Duration("backoff", wait),
+	)
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.Int("status", statusCode))
+	}
+
+	c.logger.LogAttrs(ctx, c.logLevels.Retry.Level(), "yelp request retrying", attrs...)
+}
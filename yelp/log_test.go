@@ -0,0 +1,125 @@
+package yelp_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setupWithLogger(t *testing.T, handler http.HandlerFunc, levels *yelp.LogLevels) (*yelp.Client, *bytes.Buffer) {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	var buf bytes.Buffer
+	client, _ := yelp.Init(&yelp.ClientOptions{
+		APIKey: "secret-yelp-key",
+		RetryPolicy: &yelp.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     10 * time.Millisecond,
+		},
+		Logger:    slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		LogLevels: levels,
+	})
+	client.BaseURI = ts.URL
+
+	return client, &buf
+}
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := make(map[string]interface{})
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLoggerLogsRetriesAndSuccess(t *testing.T) {
+	// Arrange
+	var requests int32
+	client, buf := setupWithLogger(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}, nil)
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "en_CA")
+
+	// Assert
+	assert.NoError(t, err)
+
+	records := logRecords(t, buf)
+	assert.Len(t, records, 2)
+
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, "yelp request retrying", records[0]["msg"])
+	assert.Equal(t, float64(503), records[0]["status"])
+	assert.Equal(t, float64(1), records[0]["attempt"])
+
+	assert.Equal(t, "DEBUG", records[1]["level"])
+	assert.Equal(t, "yelp request", records[1]["msg"])
+	assert.Equal(t, yelp.OPERATION_BUSINESS_REVIEWS, records[1]["operation"])
+	assert.Equal(t, "GET", records[1]["method"])
+	assert.Equal(t, "/businesses/review12345/reviews", records[1]["endpoint"])
+	assert.Equal(t, "locale=en_CA", records[1]["query"])
+	assert.Equal(t, float64(200), records[1]["status"])
+	assert.Equal(t, float64(2), records[1]["attempts"])
+	assert.Contains(t, records[1], "duration")
+}
+
+func TestLoggerLogsFailuresAtConfiguredLevel(t *testing.T) {
+	// Arrange
+	client, buf := setupWithLogger(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"error": {"code": "BUSINESS_NOT_FOUND", "description": "The requested business could not be found."}}`)
+	}, &yelp.LogLevels{Failure: slog.LevelWarn})
+
+	// Act
+	_, err := client.BusinessDetails("missing", "")
+
+	// Assert
+	assert.Error(t, err)
+
+	records := logRecords(t, buf)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, "yelp request failed", records[0]["msg"])
+	assert.Equal(t, float64(404), records[0]["status"])
+	assert.Equal(t, "BUSINESS_NOT_FOUND", records[0]["error_code"])
+}
+
+func TestLoggerRedactsAPIKey(t *testing.T) {
+	// Arrange
+	var authorization string
+	client, buf := setupWithLogger(t, func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, AUTOCOMPLETE_RESPONSE)
+	}, nil)
+
+	// Act
+	_, err := client.Autocomplete(yelp.BusinessAutocompleteReq{Text: "secret-yelp-key", Coordinates: yelp.Coordinates{Latitude: 37.786882, Longitude: -122.399972}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer secret-yelp-key", authorization)
+	assert.NotContains(t, buf.String(), "secret-yelp-key")
+	assert.Contains(t, buf.String(), "text="+yelp.REDACTED)
+}
@@ -0,0 +1,83 @@
+package yelp
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitInfo is the quota information Yelp sends along with every response
+type RateLimitInfo struct {
+	DailyLimit int       // Number of requests allowed per day, from the RateLimit-DailyLimit header
+	Remaining  int       // Number of requests left for the day, from the RateLimit-Remaining header
+	ResetTime  time.Time // Time at which the daily quota resets, from the RateLimit-ResetTime header
+}
+
+// ResponseMeta carries information about the HTTP response a payload was decoded from.
+// It is embedded in every response payload and is never part of the JSON body
+type ResponseMeta struct {
+	RateLimit RateLimitInfo `json:"-"` // Quota information of the response. Zero valued if Yelp didn't send the headers or the payload came from the cache
+	CacheHit  bool          `json:"-"` // Whether the payload was served from the response cache rather than by Yelp
+}
+
+// setMeta is promoted to every response payload embedding ResponseMeta so dispatchRequest can fill it in.
+func (m *ResponseMeta) setMeta(meta ResponseMeta) {
+	*m = meta
+}
+
+// metaSetter is implemented by pointers to response payloads embedding ResponseMeta.
+type metaSetter interface {
+	setMeta(meta ResponseMeta)
+}
+
+// parseRateLimitInfo reads the RateLimit-* headers, reporting false when none of them are present.
+func parseRateLimitInfo(h http.Header) (RateLimitInfo, bool) {
+	var info RateLimitInfo
+	found := false
+
+	if v, err := strconv.Atoi(h.Get("RateLimit-DailyLimit")); err == nil {
+		info.DailyLimit = v
+		found = true
+	}
+
+	if v, err := strconv.Atoi(h.Get("RateLimit-Remaining")); err == nil {
+		info.Remaining = v
+		found = true
+	}
+
+	if v, err := time.Parse(time.RFC3339, h.Get("RateLimit-ResetTime")); err == nil {
+		info.ResetTime = v
+		found = true
+	}
+
+	return info, found
+}
+
+// observeRateLimit records the quota information of a response as the last seen snapshot.
+func (c *Client) observeRateLimit(ctx context.Context, h http.Header) {
+	info, ok := parseRateLimitInfo(h)
+	if !ok {
+		return
+	}
+
+	for _, h := range c.hooks {
+		if h.RateLimit != nil {
+			h.RateLimit(ctx, info)
+		}
+	}
+
+	c.mu.Lock()
+	c.lastRateLimit = info
+	c.rateLimitSeen = true
+	c.mu.Unlock()
+}
+
+// LastRateLimit returns the quota information of the most recent response that carried RateLimit headers.
+// The second return value is false until such a response has been received.
+func (c *Client) LastRateLimit() (RateLimitInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lastRateLimit, c.rateLimitSeen
+}
@@ -0,0 +1,110 @@
+package yelp
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Doer sends an HTTP request and returns its response. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer sending requests to Yelp, for example to log, sign or fail requests.
+// Middlewares run once per attempt, so a retried request goes through them again, while cached responses don't reach them.
+// A middleware modifying the request should modify a clone of it, as http.RoundTripper implementations do.
+type Middleware func(next Doer) Doer
+
+// operationKey is the context key holding the operation name of a request.
+type operationKey struct{}
+
+// OperationFromContext returns the operation name, for example OPERATION_BUSINESS_SEARCH, of the request
+// the context belongs to. It lets middlewares tell apart the APIs requests are sent to.
+func OperationFromContext(ctx context.Context) (string, bool) {
+	operation, ok := ctx.Value(operationKey{}).(string)
+	return operation, ok
+}
+
+// Use appends middlewares to the chain wrapping the HTTP client. The first middleware added is the outermost,
+// seeing requests first and responses last. Use is meant to be called while setting up the client, before sending requests.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// doer returns the HTTP client wrapped in the middleware chain.
+func (c *Client) doer() Doer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var d Doer = c.HTTPClient
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		d = c.middlewares[i](d)
+	}
+
+	return d
+}
+
+// LoggingMiddleware logs the method, URL, status and duration of every request to logger, or to the standard logger when nil.
+// Headers, including the API key, are never logged.
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.Do(req)
+			elapsed := time.Since(start)
+
+			if err != nil {
+				logger.Printf("yelp: %s %s failed after %v: %v", req.Method, req.URL.RequestURI(), elapsed, err)
+				return res, err
+			}
+
+			logger.Printf("yelp: %s %s %d %v", req.Method, req.URL.RequestURI(), res.StatusCode, elapsed)
+			return res, nil
+		})
+	}
+}
+
+// TimingMiddleware calls observe with the operation name and duration of every request, along with its response or error.
+// The response body must not be read by observe.
+func TimingMiddleware(observe func(operation string, elapsed time.Duration, res *http.Response, err error)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.Do(req)
+
+			operation, _ := OperationFromContext(req.Context())
+			observe(operation, time.Since(start), res, err)
+
+			return res, err
+		})
+	}
+}
+
+// UserAgentMiddleware sets the User-Agent header of every request to userAgent.
+func UserAgentMiddleware(userAgent string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("User-Agent", userAgent)
+
+			return next.Do(req)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package yelp_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setupMiddlewareServer(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-User-Agent-Echo", r.UserAgent())
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+}
+
+func TestUseRunsMiddlewaresInOrder(t *testing.T) {
+	// Arrange
+	var requests int32
+	ts := setupMiddlewareServer(&requests)
+	defer ts.Close()
+
+	client := setup()
+	client.BaseURI = ts.URL
+
+	var calls []string
+	trace := func(name string) yelp.Middleware {
+		return func(next yelp.Doer) yelp.Doer {
+			return yelp.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				res, err := next.Do(req)
+				calls = append(calls, name+" after")
+				return res, err
+			})
+		}
+	}
+
+	client.Use(trace("outer"), trace("inner"))
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, calls)
+}
+
+func TestMiddlewareFaultInjectionIsRetried(t *testing.T) {
+	// Arrange
+	var requests int32
+	ts := setupMiddlewareServer(&requests)
+	defer ts.Close()
+
+	client := setupWithRetry()
+	client.BaseURI = ts.URL
+
+	var attempts int32
+	client.Use(func(next yelp.Doer) yelp.Doer {
+		return yelp.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("injected fault")}
+			}
+			return next.Do(req)
+		})
+	})
+
+	// Act
+	res, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestMiddlewaresFromClientOptions(t *testing.T) {
+	// Arrange
+	var requests int32
+	ts := setupMiddlewareServer(&requests)
+	defer ts.Close()
+
+	var userAgent string
+	capture := func(next yelp.Doer) yelp.Doer {
+		return yelp.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			res, err := next.Do(req)
+			userAgent = res.Header.Get("X-User-Agent-Echo")
+			return res, err
+		})
+	}
+
+	client, _ := yelp.Init(&yelp.ClientOptions{
+		APIKey:      "yelp-key",
+		Middlewares: []yelp.Middleware{capture, yelp.UserAgentMiddleware("enrichment-pipeline/1.0")},
+	})
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "enrichment-pipeline/1.0", userAgent)
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	// Arrange
+	var requests int32
+	ts := setupMiddlewareServer(&requests)
+	defer ts.Close()
+
+	client := setup()
+	client.BaseURI = ts.URL
+
+	var buf bytes.Buffer
+	client.Use(yelp.LoggingMiddleware(log.New(&buf, "", 0)))
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "en_CA")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "yelp: GET /businesses/review12345/reviews?locale=en_CA 200")
+	assert.NotContains(t, buf.String(), "yelp-key")
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	// Arrange
+	var requests int32
+	ts := setupMiddlewareServer(&requests)
+	defer ts.Close()
+
+	client := setup()
+	client.BaseURI = ts.URL
+
+	var operation string
+	var elapsed time.Duration
+	var status int
+	client.Use(yelp.TimingMiddleware(func(op string, d time.Duration, res *http.Response, err error) {
+		operation, elapsed, status = op, d, res.StatusCode
+	}))
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, yelp.OPERATION_BUSINESS_REVIEWS, operation)
+	assert.Greater(t, elapsed, time.Duration(0))
+	assert.Equal(t, 200, status)
+}
@@ -0,0 +1,131 @@
+// Package otelyelp instruments a yelp.Client with OpenTelemetry through its call hooks.
+// Every call gets a client span, and the following instruments are recorded:
+//
+//	yelp.client.request.duration  histogram of the duration of calls in seconds, by operation and status code
+//	yelp.client.errors            counter of failed calls, by operation and Yelp error code
+//	yelp.client.retries           counter of retried attempts, by operation
+//	yelp.client.quota.remaining   gauge of the daily quota left, from the RateLimit-Remaining header
+//
+// It lives in its own module so the yelp package doesn't depend on OpenTelemetry.
+//
+//	hooks, err := otelyelp.NewHooks(&otelyelp.Options{TracerProvider: tp, MeterProvider: mp})
+//	client, err := yelp.Init(&yelp.ClientOptions{APIKey: key, CallHooks: []yelp.CallHooks{hooks}})
+package otelyelp
+
+import (
+	"context"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+// INSTRUMENTATION_NAME is the name of the tracer and meter the hooks record their telemetry with
+const INSTRUMENTATION_NAME = "github.com/naguigui/yelp-fusion/yelp/otelyelp"
+
+// Attribute keys set on the spans and metrics of the Client
+const (
+	ATTRIBUTE_KEY_OPERATION   = attribute.Key("yelp.operation")
+	ATTRIBUTE_KEY_RETRY_COUNT = attribute.Key("yelp.retry_count")
+	ATTRIBUTE_KEY_ERROR_CODE  = attribute.Key("yelp.error_code")
+	ATTRIBUTE_KEY_CACHE_HIT   = attribute.Key("yelp.cache_hit")
+	ATTRIBUTE_KEY_STATUS_CODE = attribute.Key("http.response.status_code")
+)
+
+// Options configures the providers the hooks record with.
+type Options struct {
+	TracerProvider trace.TracerProvider // Optional. Provider spans are created with. Defaults to the global provider
+	MeterProvider  metric.MeterProvider // Optional. Provider metrics are recorded with. Defaults to the global provider
+}
+
+// telemetry holds the tracer and instruments of the hooks.
+type telemetry struct {
+	tracer    trace.Tracer
+	duration  metric.Float64Histogram
+	errors    metric.Int64Counter
+	retries   metric.Int64Counter
+	remaining metric.Int64Gauge
+}
+
+// NewHooks creates the call hooks recording spans and metrics with the providers of the options. Options may be nil.
+func NewHooks(opts *Options) (yelp.CallHooks, error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.TracerProvider == nil {
+		o.TracerProvider = otel.GetTracerProvider()
+	}
+	if o.MeterProvider == nil {
+		o.MeterProvider = otel.GetMeterProvider()
+	}
+
+	meter := o.MeterProvider.Meter(INSTRUMENTATION_NAME)
+	t := &telemetry{tracer: o.TracerProvider.Tracer(INSTRUMENTATION_NAME)}
+
+	var err error
+	if t.duration, err = meter.Float64Histogram("yelp.client.request.duration", metric.WithUnit("s"), metric.WithDescription("Duration of Yelp API calls, including retries")); err != nil {
+		return yelp.CallHooks{}, err
+	}
+
+	if t.errors, err = meter.Int64Counter("yelp.client.errors", metric.WithDescription("Number of failed Yelp API calls")); err != nil {
+		return yelp.CallHooks{}, err
+	}
+
+	if t.retries, err = meter.Int64Counter("yelp.client.retries", metric.WithDescription("Number of retried Yelp API attempts")); err != nil {
+		return yelp.CallHooks{}, err
+	}
+
+	if t.remaining, err = meter.Int64Gauge("yelp.client.quota.remaining", metric.WithDescription("Daily Yelp API quota left, as reported by Yelp")); err != nil {
+		return yelp.CallHooks{}, err
+	}
+
+	return yelp.CallHooks{Start: t.start, End: t.end, RateLimit: t.recordQuota}, nil
+}
+
+// start starts the client span of a call to the operation.
+func (t *telemetry) start(ctx context.Context, operation string) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "yelp."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(ATTRIBUTE_KEY_OPERATION.String(operation), attribute.String("http.request.method", http.MethodGet)),
+	)
+
+	return ctx
+}
+
+// end ends the span of a call and records its metrics.
+func (t *telemetry) end(ctx context.Context, call yelp.CallInfo) {
+	span := trace.SpanFromContext(ctx)
+	operationAttr := ATTRIBUTE_KEY_OPERATION.String(call.Operation)
+	span.SetAttributes(ATTRIBUTE_KEY_RETRY_COUNT.Int(call.Retries), ATTRIBUTE_KEY_CACHE_HIT.Bool(call.CacheHit))
+
+	if call.StatusCode != 0 {
+		span.SetAttributes(ATTRIBUTE_KEY_STATUS_CODE.Int(call.StatusCode))
+	}
+
+	if call.Err != nil {
+		if call.ErrorCode != "" {
+			span.SetAttributes(ATTRIBUTE_KEY_ERROR_CODE.String(call.ErrorCode))
+		}
+		span.RecordError(call.Err)
+		span.SetStatus(codes.Error, call.Err.Error())
+
+		t.errors.Add(ctx, 1, metric.WithAttributes(operationAttr, ATTRIBUTE_KEY_ERROR_CODE.String(call.ErrorCode)))
+	}
+
+	if call.Retries > 0 {
+		t.retries.Add(ctx, int64(call.Retries), metric.WithAttributes(operationAttr))
+	}
+
+	t.duration.Record(ctx, call.Duration.Seconds(), metric.WithAttributes(operationAttr, ATTRIBUTE_KEY_STATUS_CODE.Int(call.StatusCode)))
+	span.End()
+}
+
+// recordQuota records the daily quota left reported by a response.
+func (t *telemetry) recordQuota(ctx context.Context, info yelp.RateLimitInfo) {
+	t.remaining.Record(ctx, int64(info.Remaining))
+}
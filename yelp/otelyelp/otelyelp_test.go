@@ -0,0 +1,148 @@
+package otelyelp_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/otelyelp"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const BUSINESS_REVIEWS_RESPONSE = `{"reviews": [{"id": "review12345", "rating": 5, "text": "Great food."}], "total": 1, "possible_languages": ["en"]}`
+
+func setupWithTelemetry(t *testing.T, handler http.HandlerFunc) (*yelp.Client, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	hooks, err := otelyelp.NewHooks(&otelyelp.Options{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	assert.NoError(t, err)
+
+	client, err := yelp.Init(&yelp.ClientOptions{
+		APIKey: "yelp-key",
+		RetryPolicy: &yelp.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     10 * time.Millisecond,
+		},
+		CallHooks: []yelp.CallHooks{hooks},
+	})
+	assert.NoError(t, err)
+	client.BaseURI = ts.URL
+
+	return client, spans, reader
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func collectMetrics(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func TestTelemetrySuccessfulCall(t *testing.T) {
+	// Arrange
+	var requests int32
+	client, spans, reader := setupWithTelemetry(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("RateLimit-Remaining", "4999")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	})
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, err)
+
+	recorded := spans.GetSpans()
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, "yelp.BusinessReviews", recorded[0].Name)
+	assert.Equal(t, codes.Unset, recorded[0].Status.Code)
+
+	attrs := spanAttributes(recorded[0])
+	assert.Equal(t, yelp.OPERATION_BUSINESS_REVIEWS, attrs[otelyelp.ATTRIBUTE_KEY_OPERATION].AsString())
+	assert.Equal(t, int64(200), attrs[otelyelp.ATTRIBUTE_KEY_STATUS_CODE].AsInt64())
+	assert.Equal(t, int64(1), attrs[otelyelp.ATTRIBUTE_KEY_RETRY_COUNT].AsInt64())
+	assert.False(t, attrs[otelyelp.ATTRIBUTE_KEY_CACHE_HIT].AsBool())
+
+	metrics := collectMetrics(t, reader)
+
+	duration := metrics["yelp.client.request.duration"].(metricdata.Histogram[float64])
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+
+	retries := metrics["yelp.client.retries"].(metricdata.Sum[int64])
+	assert.Equal(t, int64(1), retries.DataPoints[0].Value)
+
+	remaining := metrics["yelp.client.quota.remaining"].(metricdata.Gauge[int64])
+	assert.Equal(t, int64(4999), remaining.DataPoints[0].Value)
+
+	_, hasErrors := metrics["yelp.client.errors"]
+	assert.False(t, hasErrors)
+}
+
+func TestTelemetryFailedCall(t *testing.T) {
+	// Arrange
+	client, spans, reader := setupWithTelemetry(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"error": {"code": "BUSINESS_NOT_FOUND", "description": "The requested business could not be found."}}`)
+	})
+
+	// Act
+	_, err := client.BusinessDetails("missing", "")
+
+	// Assert
+	assert.ErrorIs(t, err, yelp.ErrBusinessNotFound)
+
+	recorded := spans.GetSpans()
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, codes.Error, recorded[0].Status.Code)
+
+	attrs := spanAttributes(recorded[0])
+	assert.Equal(t, int64(404), attrs[otelyelp.ATTRIBUTE_KEY_STATUS_CODE].AsInt64())
+	assert.Equal(t, "BUSINESS_NOT_FOUND", attrs[otelyelp.ATTRIBUTE_KEY_ERROR_CODE].AsString())
+	assert.Equal(t, int64(0), attrs[otelyelp.ATTRIBUTE_KEY_RETRY_COUNT].AsInt64())
+
+	metrics := collectMetrics(t, reader)
+
+	errors := metrics["yelp.client.errors"].(metricdata.Sum[int64])
+	assert.Equal(t, int64(1), errors.DataPoints[0].Value)
+
+	code, _ := errors.DataPoints[0].Attributes.Value(otelyelp.ATTRIBUTE_KEY_ERROR_CODE)
+	assert.Equal(t, "BUSINESS_NOT_FOUND", code.AsString())
+}
@@ -0,0 +1,123 @@
+package yelp
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig configures a RateLimiter. Zero valued limits are not enforced.
+type RateLimitConfig struct {
+	QPS        float64 // Maximum sustained number of requests per second
+	Burst      int     // Number of requests that may be sent at once before QPS kicks in. Defaults to QPS rounded up
+	DailyLimit int     // Maximum number of requests per day. Yelp resets the daily quota at midnight UTC
+	FailFast   bool    // When true, requests over budget fail with ErrRateLimited instead of waiting for capacity
+}
+
+// RateLimiter is a token bucket limiter enforcing a per second and a daily request budget.
+// A single RateLimiter is safe for concurrent use and can be shared by several Clients.
+type RateLimiter struct {
+	config RateLimitConfig
+
+	mu        sync.Mutex
+	tokens    float64
+	last      time.Time
+	day       time.Time
+	dailyUsed int
+}
+
+// NewRateLimiter creates a RateLimiter from the given config, starting with a full bucket.
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if config.QPS > 0 && config.Burst <= 0 {
+		config.Burst = int(math.Ceil(config.QPS))
+	}
+
+	now := time.Now()
+
+	return &RateLimiter{
+		config: config,
+		tokens: float64(config.Burst),
+		last:   now,
+		day:    startOfDay(now),
+	}
+}
+
+// startOfDay truncates t to midnight UTC.
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// Wait blocks until a request may be sent, or returns ErrRateLimited straight away when FailFast is set.
+// It returns the context error if the context is done before capacity is available.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, err := r.reserve()
+		if err != nil {
+			return err
+		}
+
+		if wait == 0 {
+			return nil
+		}
+
+		if err = sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a request from the daily and per second budgets if both have capacity.
+// Otherwise it returns how long to wait before trying again.
+func (r *RateLimiter) reserve() (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	if today := startOfDay(now); today.After(r.day) {
+		r.day = today
+		r.dailyUsed = 0
+	}
+
+	if r.config.DailyLimit > 0 && r.dailyUsed >= r.config.DailyLimit {
+		if r.config.FailFast {
+			return 0, ErrRateLimited
+		}
+		return r.day.Add(24 * time.Hour).Sub(now), nil
+	}
+
+	if r.config.QPS > 0 {
+		r.tokens = math.Min(float64(r.config.Burst), r.tokens+now.Sub(r.last).Seconds()*r.config.QPS)
+		r.last = now
+
+		if r.tokens < 1 {
+			if r.config.FailFast {
+				return 0, ErrRateLimited
+			}
+			return time.Duration((1 - r.tokens) / r.config.QPS * float64(time.Second)), nil
+		}
+
+		r.tokens--
+	}
+
+	r.dailyUsed++
+
+	return 0, nil
+}
+
+// DailyRemaining returns the number of requests left in today's budget, or -1 when no daily limit is set.
+func (r *RateLimiter) DailyRemaining() int {
+	if r.config.DailyLimit <= 0 {
+		return -1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if startOfDay(time.Now()).After(r.day) {
+		return r.config.DailyLimit
+	}
+
+	return r.config.DailyLimit - r.dailyUsed
+}
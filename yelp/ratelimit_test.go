@@ -0,0 +1,84 @@
+package yelp_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setupWithRateLimiter(config yelp.RateLimitConfig) (*yelp.Client, *httptest.Server, *int32) {
+	var requests int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+
+	client, _ := yelp.Init(&yelp.ClientOptions{APIKey: "yelp-key", RateLimiter: yelp.NewRateLimiter(config)})
+	client.BaseURI = ts.URL
+
+	return client, ts, &requests
+}
+
+func TestRateLimiterBlocksUntilTokensAreAvailable(t *testing.T) {
+	// Arrange
+	client, ts, requests := setupWithRateLimiter(yelp.RateLimitConfig{QPS: 20, Burst: 1})
+	defer ts.Close()
+
+	start := time.Now()
+
+	// Act
+	for i := 0; i < 3; i++ {
+		if _, err := client.BusinessReviews("review12345", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Assert
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestRateLimiterFailFast(t *testing.T) {
+	// Arrange
+	client, ts, requests := setupWithRateLimiter(yelp.RateLimitConfig{QPS: 1, Burst: 1, FailFast: true})
+	defer ts.Close()
+
+	// Act
+	_, firstErr := client.BusinessReviews("review12345", "")
+	_, secondErr := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.True(t, errors.Is(secondErr, yelp.ErrRateLimited))
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestRateLimiterDailyLimit(t *testing.T) {
+	// Arrange
+	limiter := yelp.NewRateLimiter(yelp.RateLimitConfig{DailyLimit: 2})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	first := limiter.Wait(ctx)
+	second := limiter.Wait(ctx)
+	remaining := limiter.DailyRemaining()
+	third := limiter.Wait(ctx)
+
+	// Assert
+	assert.NoError(t, first)
+	assert.NoError(t, second)
+	assert.Equal(t, 0, remaining)
+	assert.True(t, errors.Is(third, context.DeadlineExceeded))
+}
@@ -0,0 +1,232 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RETRY_NO_JITTER is the RetryPolicy.Jitter disabling jitter, so retries wait exactly the computed backoff
+const RETRY_NO_JITTER = -1
+
+// RetryPolicy configures how the Client retries requests that fail with a retryable status code or network error.
+// Zero valued fields fall back to the values of DefaultRetryPolicy. Set Jitter to RETRY_NO_JITTER to disable jitter.
+type RetryPolicy struct {
+	MaxAttempts          int                  // Total number of attempts, including the first one. A value of 1 disables retries
+	InitialBackoff       time.Duration        // Wait before the first retry
+	MaxBackoff           time.Duration        // Upper bound of the wait between two attempts, including waits requested by Retry-After
+	Multiplier           float64              // Factor the backoff grows by after every attempt
+	Jitter               float64              // Fraction from 0 to 1 of the backoff that is randomized, so that concurrent clients don't retry in lockstep. Negative values disable jitter
+	RetryableStatusCodes []int                // HTTP status codes that are retried
+	RetryableError       func(err error) bool // Reports whether a transport error is retried. Defaults to connection and timeout errors
+}
+
+// DefaultRetryPolicy returns a policy retrying up to 3 times on 429 and 5xx responses as well as network errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableError: isRetryableNetworkError,
+	}
+}
+
+// withDefaults returns a copy of the policy where unset fields are filled in from DefaultRetryPolicy.
+func (p RetryPolicy) withDefaults() *RetryPolicy {
+	d := DefaultRetryPolicy()
+
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = d.MaxAttempts
+	}
+
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = d.InitialBackoff
+	}
+
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = d.MaxBackoff
+	}
+
+	if p.Multiplier < 1 {
+		p.Multiplier = d.Multiplier
+	}
+
+	if p.Jitter == 0 || p.Jitter > 1 {
+		p.Jitter = d.Jitter
+	}
+
+	if p.RetryableStatusCodes == nil {
+		p.RetryableStatusCodes = d.RetryableStatusCodes
+	}
+
+	if p.RetryableError == nil {
+		p.RetryableError = d.RetryableError
+	}
+
+	return &p
+}
+
+// retryableStatus reports whether the status code is listed in RetryableStatusCodes.
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff computes the jittered wait before the given retry, starting at 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(retry-1))
+
+	if d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		d = d * (1 - p.Jitter + 2*p.Jitter*rand.Float64())
+	}
+
+	return time.Duration(d)
+}
+
+// isRetryableNetworkError reports whether err is a connection or timeout error worth retrying.
+// Cancelled requests and expired deadlines are never retried.
+func isRetryableNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// parseRetryAfter reads the Retry-After header, given either in seconds or as an HTTP date.
+func parseRetryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// sleep waits for d or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// send dispatches the request, retrying according to the Client RetryPolicy.
+// The response body is fully read and closed, and returned alongside the response.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	ctx := req.Context()
+
+	policy := &RetryPolicy{MaxAttempts: 1}
+	if c.RetryPolicy != nil {
+		policy = c.RetryPolicy.withDefaults()
+	}
+
+	doer := c.doer()
+
+	for attempt := 1; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		countAttempt(ctx)
+
+		res, err := doer.Do(req.Clone(ctx))
+		if err != nil {
+			if attempt >= policy.MaxAttempts || !policy.RetryableError(err) {
+				return nil, nil, err
+			}
+
+			wait := policy.backoff(attempt)
+			c.logRetry(ctx, req, attempt, wait, 0, err)
+
+			if err = sleep(ctx, wait); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		c.observeRateLimit(ctx, res.Header)
+
+		data, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if attempt >= policy.MaxAttempts || !policy.retryableStatus(res.StatusCode) {
+			return res, data, nil
+		}
+
+		wait := policy.backoff(attempt)
+		if d, ok := parseRetryAfter(res.Header); ok {
+			wait = d
+			if wait > policy.MaxBackoff {
+				wait = policy.MaxBackoff
+			}
+		}
+
+		c.logRetry(ctx, req, attempt, wait, res.StatusCode, nil)
+
+		if err = sleep(ctx, wait); err != nil {
+			return nil, nil, err
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package yelp_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setupWithRetry() *yelp.Client {
+	client, _ := yelp.Init(&yelp.ClientOptions{
+		APIKey: "yelp-key",
+		RetryPolicy: &yelp.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     10 * time.Millisecond,
+		},
+	})
+
+	return client
+}
+
+func TestRetrySucceedsAfterServerErrors(t *testing.T) {
+	// Arrange
+	client := setupWithRetry()
+
+	var attempts int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	res, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	// Arrange
+	client, _ := yelp.Init(&yelp.ClientOptions{
+		APIKey:      "yelp-key",
+		RetryPolicy: &yelp.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Second},
+	})
+
+	var attempts []time.Time
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, time.Now())
+		if len(attempts) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(429)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 2)
+	delay := attempts[1].Sub(attempts[0])
+	assert.True(t, delay >= time.Second, "retried after %v instead of the 1s Retry-After", delay)
+	assert.True(t, delay < 5*time.Second, "retried after %v", delay)
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	client := setupWithRetry()
+
+	var attempts int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(500)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	var apiErr *yelp.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 500, apiErr.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestRetrySkipsNonRetryableStatus(t *testing.T) {
+	// Arrange
+	client := setupWithRetry()
+
+	var attempts int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(400)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.EqualError(t, err, "400 Bad Request")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryWithoutJitterWaitsTheBackoff(t *testing.T) {
+	// Arrange
+	client, _ := yelp.Init(&yelp.ClientOptions{
+		APIKey:      "yelp-key",
+		RetryPolicy: &yelp.RetryPolicy{MaxAttempts: 2, InitialBackoff: 50 * time.Millisecond, Jitter: yelp.RETRY_NO_JITTER},
+	})
+
+	var attempts []time.Time
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, time.Now())
+		if len(attempts) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessReviews("review12345", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 2)
+	assert.True(t, attempts[1].Sub(attempts[0]) >= 50*time.Millisecond, "retried after %v", attempts[1].Sub(attempts[0]))
+}
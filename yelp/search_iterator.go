@@ -0,0 +1,154 @@
+package yelp
+
+import "context"
+
+const (
+	BUSINESS_SEARCH_MAX_LIMIT   = 50   // Maximum number of businesses Yelp returns in a single Business Search page
+	BUSINESS_SEARCH_MAX_RESULTS = 1000 // Maximum value of Limit + Offset accepted by Business Search
+)
+
+// BusinessSearchIteratorOptions configures how a BusinessSearchIterator walks the result pages.
+type BusinessSearchIteratorOptions struct {
+	MaxResults     int  // Optional. Stop after this many businesses have been returned. Defaults to every available result
+	SkipDuplicates bool // Optional. Drop businesses whose ID was already returned on a previous page
+}
+
+// BusinessSearchIterator walks every page of a Business Search, fetching pages on demand.
+// It respects the 50 per page and 1000 results caps of the API and stops once Total is reached.
+//
+//	it := client.NewBusinessSearchIterator(ctx, params, nil)
+//	for it.Next() {
+//		business := it.Business()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type BusinessSearchIterator struct {
+	client  *Client
+	ctx     context.Context
+	req     BusinessSearchReq
+	options BusinessSearchIteratorOptions
+
+	page     []Business
+	index    int
+	offset   int
+	returned int
+	total    int
+	fetched  bool
+	done     bool
+	seen     map[string]struct{}
+	current  Business
+	err      error
+}
+
+// NewBusinessSearchIterator creates an iterator over the results of the given search request.
+// Limit is used as the page size, capped to 50, and Offset as the starting position. Options may be nil.
+func (c *Client) NewBusinessSearchIterator(ctx context.Context, b BusinessSearchReq, options *BusinessSearchIteratorOptions) *BusinessSearchIterator {
+	it := &BusinessSearchIterator{client: c, ctx: ctx, req: b, offset: b.Offset}
+
+	if options != nil {
+		it.options = *options
+	}
+
+	if it.options.SkipDuplicates {
+		it.seen = make(map[string]struct{})
+	}
+
+	if it.req.Limit <= 0 || it.req.Limit > BUSINESS_SEARCH_MAX_LIMIT {
+		it.req.Limit = BUSINESS_SEARCH_MAX_LIMIT
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next business, fetching the next page when needed.
+// It returns false once every result has been returned or an error occurred.
+func (it *BusinessSearchIterator) Next() bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+
+		if it.options.MaxResults > 0 && it.returned >= it.options.MaxResults {
+			return false
+		}
+
+		if it.index >= len(it.page) {
+			if it.done {
+				return false
+			}
+
+			it.fetch()
+			continue
+		}
+
+		business := it.page[it.index]
+		it.index++
+
+		if it.seen != nil {
+			if _, ok := it.seen[business.ID]; ok {
+				continue
+			}
+			it.seen[business.ID] = struct{}{}
+		}
+
+		it.current = business
+		it.returned++
+
+		return true
+	}
+}
+
+// fetch requests the page starting at the current offset.
+func (it *BusinessSearchIterator) fetch() {
+	limit := it.req.Limit
+
+	if remaining := BUSINESS_SEARCH_MAX_RESULTS - it.offset; remaining < limit {
+		limit = remaining
+	}
+
+	if it.fetched && it.total-it.offset < limit {
+		limit = it.total - it.offset
+	}
+
+	if limit <= 0 {
+		it.done = true
+		it.page = nil
+		return
+	}
+
+	req := it.req
+	req.Limit = limit
+	req.Offset = it.offset
+
+	res, err := it.client.BusinessSearchWithContext(it.ctx, req)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.fetched = true
+	it.total = res.Total
+	it.page = res.Businesses
+	it.index = 0
+	it.offset += len(res.Businesses)
+
+	if len(res.Businesses) == 0 || it.offset >= it.total || it.offset >= BUSINESS_SEARCH_MAX_RESULTS {
+		it.done = true
+	}
+}
+
+// Business returns the business the iterator currently points to.
+func (it *BusinessSearchIterator) Business() Business {
+	return it.current
+}
+
+// Total returns the total number of results reported by Yelp, or 0 before the first page is fetched.
+func (it *BusinessSearchIterator) Total() int {
+	return it.total
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *BusinessSearchIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,140 @@
+package yelp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// pagingServer serves total businesses with IDs biz-0 to biz-(total-1), honoring limit and offset.
+// When overlap is set, every page repeats the last business of the previous page.
+func pagingServer(total int, overlap bool, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		start := offset
+		if overlap && start > 0 {
+			start--
+		}
+
+		res := yelp.BusinessSearchRes{Total: total}
+		for i := start; i < offset+limit && i < total; i++ {
+			res.Businesses = append(res.Businesses, yelp.Business{ID: fmt.Sprintf("biz-%d", i)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		json.NewEncoder(w).Encode(res)
+	}))
+}
+
+func TestBusinessSearchIteratorWalksAllPages(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var requests []string
+	ts := pagingServer(120, false, &requests)
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	params := yelp.BusinessSearchReq{Term: "restaurant", Location: "222 Yonge St. Toronto, ON"}
+
+	// Act
+	it := client.NewBusinessSearchIterator(context.Background(), params, nil)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Business().ID)
+	}
+
+	// Assert
+	assert.NoError(t, it.Err())
+	assert.Len(t, ids, 120)
+	assert.Equal(t, "biz-119", ids[119])
+	assert.Equal(t, 120, it.Total())
+	assert.Len(t, requests, 3)
+	assert.Contains(t, requests[2], "limit=20")
+	assert.Contains(t, requests[2], "offset=100")
+}
+
+func TestBusinessSearchIteratorStopsAtResultCap(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var requests []string
+	ts := pagingServer(5000, false, &requests)
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	params := yelp.BusinessSearchReq{Location: "Toronto, ON", Limit: 50, Offset: 980}
+
+	// Act
+	it := client.NewBusinessSearchIterator(context.Background(), params, nil)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+
+	// Assert
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 20, count)
+	assert.Len(t, requests, 1)
+}
+
+func TestBusinessSearchIteratorSkipsDuplicates(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var requests []string
+	ts := pagingServer(60, true, &requests)
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	params := yelp.BusinessSearchReq{Location: "Toronto, ON", Limit: 30}
+
+	// Act
+	it := client.NewBusinessSearchIterator(context.Background(), params, &yelp.BusinessSearchIteratorOptions{SkipDuplicates: true, MaxResults: 45})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Business().ID)
+	}
+
+	// Assert
+	assert.NoError(t, it.Err())
+	assert.Len(t, ids, 45)
+	assert.Equal(t, "biz-29", ids[29])
+	assert.Equal(t, "biz-30", ids[30])
+}
+
+func TestBusinessSearchIteratorError(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	it := client.NewBusinessSearchIterator(context.Background(), yelp.BusinessSearchReq{Location: "Toronto, ON"}, nil)
+
+	// Assert
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "500 Internal Server Error")
+}
@@ -0,0 +1,186 @@
+package yelp
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp/utility"
+	"strconv"
+	"strings"
+)
+
+// SortBy is the mode Business Search results are sorted by
+type SortBy string
+
+// Sort modes accepted by BusinessSearchReq.SortBy
+const (
+	SORT_BY_BEST_MATCH   SortBy = "best_match"
+	SORT_BY_RATING       SortBy = "rating"
+	SORT_BY_REVIEW_COUNT SortBy = "review_count"
+	SORT_BY_DISTANCE     SortBy = "distance"
+)
+
+// Valid reports whether the sort mode is one supported by Yelp.
+func (s SortBy) Valid() bool {
+	switch s {
+	case SORT_BY_BEST_MATCH, SORT_BY_RATING, SORT_BY_REVIEW_COUNT, SORT_BY_DISTANCE:
+		return true
+	}
+
+	return false
+}
+
+// Device platforms accepted by BusinessSearchReq.DevicePlatform
+const (
+	DEVICE_PLATFORM_ANDROID        = "android"
+	DEVICE_PLATFORM_IOS            = "ios"
+	DEVICE_PLATFORM_MOBILE_GENERIC = "mobile-generic"
+)
+
+// MAX_RESERVATION_COVERS is the largest party size accepted by BusinessSearchReq.ReservationCovers
+const MAX_RESERVATION_COVERS = 10
+
+// PriceLevel is a Yelp pricing level, from 1 ($) to 4 ($$$$)
+type PriceLevel int
+
+// Pricing levels accepted by BusinessSearchReq.Price
+const (
+	PRICE_LEVEL_1 PriceLevel = 1 // $
+	PRICE_LEVEL_2 PriceLevel = 2 // $$
+	PRICE_LEVEL_3 PriceLevel = 3 // $$$
+	PRICE_LEVEL_4 PriceLevel = 4 // $$$$
+)
+
+// ParsePriceLevel converts a price as found in Business.Price, for example "$$", to a PriceLevel.
+func ParsePriceLevel(price string) (PriceLevel, error) {
+	level := PriceLevel(len(price))
+
+	if strings.Trim(price, "$") != "" || !level.Valid() {
+		return 0, fmt.Errorf("invalid price level %q, expected $, $$, $$$ or $$$$", price)
+	}
+
+	return level, nil
+}
+
+// Valid reports whether the pricing level is between 1 and 4.
+func (p PriceLevel) Valid() bool {
+	return p >= PRICE_LEVEL_1 && p <= PRICE_LEVEL_4
+}
+
+// String formats the pricing level with dollar signs, for example "$$".
+func (p PriceLevel) String() string {
+	if !p.Valid() {
+		return strconv.Itoa(int(p))
+	}
+
+	return strings.Repeat("$", int(p))
+}
+
+// PriceLevels is a list of pricing levels. It is sent to Yelp as a comma delimited list with duplicates removed, for example "1,2,3"
+type PriceLevels []PriceLevel
+
+// MarshalJSON encodes the pricing levels in Yelp's comma delimited format.
+func (p PriceLevels) MarshalJSON() ([]byte, error) {
+	levels := make([]string, len(p))
+	for i, level := range p {
+		levels[i] = strconv.Itoa(int(level))
+	}
+
+	return json.Marshal(joinUnique(levels))
+}
+
+// Attribute is a Business Search attribute filter
+type Attribute string
+
+// Attributes accepted by BusinessSearchReq.Attributes.
+// See https://www.yelp.com/developers/documentation/v3/business_search for the full list
+const (
+	ATTRIBUTE_HOT_AND_NEW              Attribute = "hot_and_new"
+	ATTRIBUTE_REQUEST_A_QUOTE          Attribute = "request_a_quote"
+	ATTRIBUTE_RESERVATION              Attribute = "reservation"
+	ATTRIBUTE_WAITLIST_RESERVATION     Attribute = "waitlist_reservation"
+	ATTRIBUTE_DEALS                    Attribute = "deals"
+	ATTRIBUTE_GENDER_NEUTRAL_RESTROOMS Attribute = "gender_neutral_restrooms"
+	ATTRIBUTE_OPEN_TO_ALL              Attribute = "open_to_all"
+	ATTRIBUTE_WHEELCHAIR_ACCESSIBLE    Attribute = "wheelchair_accessible"
+	ATTRIBUTE_LIKED_BY_VEGETARIANS     Attribute = "liked_by_vegetarians"
+	ATTRIBUTE_OUTDOOR_SEATING          Attribute = "outdoor_seating"
+	ATTRIBUTE_PARKING_GARAGE           Attribute = "parking_garage"
+	ATTRIBUTE_PARKING_LOT              Attribute = "parking_lot"
+	ATTRIBUTE_PARKING_STREET           Attribute = "parking_street"
+	ATTRIBUTE_PARKING_VALET            Attribute = "parking_valet"
+	ATTRIBUTE_PARKING_VALIDATED        Attribute = "parking_validated"
+	ATTRIBUTE_PARKING_BIKE             Attribute = "parking_bike"
+	ATTRIBUTE_RESTAURANTS_DELIVERY     Attribute = "restaurants_delivery"
+	ATTRIBUTE_RESTAURANTS_TAKEOUT      Attribute = "restaurants_takeout"
+	ATTRIBUTE_WIFI_FREE                Attribute = "wifi_free"
+	ATTRIBUTE_WIFI_PAID                Attribute = "wifi_paid"
+)
+
+// Attributes is a set of attribute filters. It is sent to Yelp as a comma delimited list with duplicates removed
+type Attributes []Attribute
+
+// MarshalJSON encodes the attributes in Yelp's comma delimited format.
+func (a Attributes) MarshalJSON() ([]byte, error) {
+	values := make([]string, len(a))
+	for i, attribute := range a {
+		values[i] = string(attribute)
+	}
+
+	return json.Marshal(joinUnique(values))
+}
+
+// Categories is a list of category aliases, for example {"bars", "french"}. It is sent to Yelp as a comma delimited list with duplicates removed
+type Categories []string
+
+// MarshalJSON encodes the categories in Yelp's comma delimited format.
+func (c Categories) MarshalJSON() ([]byte, error) {
+	return json.Marshal(joinUnique(c))
+}
+
+// joinUnique joins values with commas, dropping duplicates while keeping the original order.
+func joinUnique(values []string) string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+
+	return strings.Join(unique, ",")
+}
+
+// validListItem reports whether v can be safely sent as an item of a comma delimited list.
+func validListItem(v string) bool {
+	return v != "" && !strings.ContainsAny(v, ", \t\n")
+}
+
+// params converts the search request to query parameters, serializing time fields in Yelp's formats.
+func (b BusinessSearchReq) params() (map[string]interface{}, error) {
+	params, err := utility.StructToMap(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+
+	// A coordinate on the equator or the prime meridian is 0, which omitempty drops
+	if b.Location == "" {
+		params["latitude"], params["longitude"] = b.Latitude, b.Longitude
+	}
+
+	if !b.OpenAt.IsZero() {
+		params["open_at"] = b.OpenAt.Unix()
+	}
+
+	if !b.ReservationAt.IsZero() {
+		params["reservation_date"] = b.ReservationAt.Format("2006-01-02")
+		params["reservation_time"] = b.ReservationAt.Format("15:04")
+	}
+
+	return params, nil
+}
@@ -1,6 +1,9 @@
 package utility
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+)
 
 func StructToMap(obj interface{}) (m map[string]interface{}, err error) {
 	data, err := json.Marshal(obj) // Convert to a json string
@@ -9,6 +12,9 @@ func StructToMap(obj interface{}) (m map[string]interface{}, err error) {
 		return
 	}
 
-	err = json.Unmarshal(data, &m)
+	// Keep numbers as json.Number so large integers such as Unix timestamps aren't formatted in exponent notation
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	err = d.Decode(&m)
 	return
 }
@@ -0,0 +1,159 @@
+package yelp
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	BUSINESS_SEARCH_DEFAULT_LIMIT = 20    // Number of businesses Yelp returns when Limit isn't set
+	BUSINESS_SEARCH_MAX_RADIUS    = 40000 // Maximum search radius in meters
+)
+
+// FieldError describes a request field that failed validation
+type FieldError struct {
+	Field   string // Name of the request parameter, as sent to Yelp, for example "limit"
+	Message string // Reason the field is invalid
+}
+
+// Error formats the field name followed by the reason it is invalid.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors is the list of every field that failed validation in a request.
+// It matches ErrValidation with errors.Is, like a VALIDATION_ERROR returned by Yelp
+type ValidationErrors []*FieldError
+
+// Error joins the message of every field error.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return "invalid request: " + strings.Join(messages, "; ")
+}
+
+// Is reports whether target is ErrValidation.
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Unwrap returns the field errors, so errors.As finds a *FieldError.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// add records a field error.
+func (e *ValidationErrors) add(field string, format string, args ...interface{}) {
+	*e = append(*e, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns nil when no field failed validation, so callers don't end up with a non nil error interface.
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Validate checks the request against the constraints documented by Yelp, returning ValidationErrors
+// listing every invalid field. BusinessSearch calls it before dispatching the request.
+func (b BusinessSearchReq) Validate() error {
+	var errs ValidationErrors
+
+	if b.Location == "" && b.Latitude == 0 && b.Longitude == 0 {
+		errs.add("location", "location is required if latitude and longitude are not provided")
+	}
+
+	if b.Latitude < -90 || b.Latitude > 90 {
+		errs.add("latitude", "must be between -90 and 90, got %v", b.Latitude)
+	}
+
+	if b.Longitude < -180 || b.Longitude > 180 {
+		errs.add("longitude", "must be between -180 and 180, got %v", b.Longitude)
+	}
+
+	if b.Radius < 0 || b.Radius > BUSINESS_SEARCH_MAX_RADIUS {
+		errs.add("radius", "must be between 0 and %d meters, got %d", BUSINESS_SEARCH_MAX_RADIUS, b.Radius)
+	}
+
+	if b.Limit < 0 || b.Limit > BUSINESS_SEARCH_MAX_LIMIT {
+		errs.add("limit", "must be between 0 and %d, got %d", BUSINESS_SEARCH_MAX_LIMIT, b.Limit)
+	}
+
+	if b.Offset < 0 {
+		errs.add("offset", "must not be negative, got %d", b.Offset)
+	}
+
+	limit := b.Limit
+	if limit == 0 {
+		limit = BUSINESS_SEARCH_DEFAULT_LIMIT
+	}
+
+	// The page is filed under offset when the offset alone is past the results, else under the limit reaching past them
+	if b.Offset+limit > BUSINESS_SEARCH_MAX_RESULTS {
+		field := "limit"
+		if b.Offset >= BUSINESS_SEARCH_MAX_RESULTS {
+			field = "offset"
+		}
+		errs.add(field, "limit + offset must not exceed %d, got %d", BUSINESS_SEARCH_MAX_RESULTS, b.Offset+limit)
+	}
+
+	if b.SortBy != "" && !b.SortBy.Valid() {
+		errs.add("sort_by", "must be one of best_match, rating, review_count or distance, got %q", b.SortBy)
+	}
+
+	if b.OpenNow && !b.OpenAt.IsZero() {
+		errs.add("open_at", "open_at and open_now cannot be used together")
+	}
+
+	for _, level := range b.Price {
+		if !level.Valid() {
+			errs.add("price", "must be levels between 1 and 4, got %d", level)
+		}
+	}
+
+	for _, attribute := range b.Attributes {
+		if !validListItem(string(attribute)) {
+			errs.add("attributes", "must not be empty or contain commas or whitespace, got %q", attribute)
+		}
+	}
+
+	for _, alias := range b.Categories {
+		if !validListItem(alias) {
+			errs.add("categories", "must not be empty or contain commas or whitespace, got %q", alias)
+		}
+	}
+
+	switch b.DevicePlatform {
+	case "", DEVICE_PLATFORM_ANDROID, DEVICE_PLATFORM_IOS, DEVICE_PLATFORM_MOBILE_GENERIC:
+	default:
+		errs.add("device_platform", "must be one of android, ios or mobile-generic, got %q", b.DevicePlatform)
+	}
+
+	if b.ReservationCovers < 0 || b.ReservationCovers > MAX_RESERVATION_COVERS {
+		errs.add("reservation_covers", "must be between 1 and %d, got %d", MAX_RESERVATION_COVERS, b.ReservationCovers)
+	}
+
+	if b.ReservationAt.IsZero() && b.ReservationCovers != 0 {
+		errs.add("reservation_date", "reservation_date and reservation_time are required with reservation_covers")
+	}
+
+	if !b.ReservationAt.IsZero() && b.ReservationCovers == 0 {
+		errs.add("reservation_covers", "reservation_covers is required with reservation_date and reservation_time")
+	}
+
+	if b.MatchesPartySizeParam && b.ReservationAt.IsZero() {
+		errs.add("matches_party_size_param", "requires a reservation search")
+	}
+
+	return errs.err()
+}
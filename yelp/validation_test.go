@@ -0,0 +1,244 @@
+package yelp_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestBusinessSearchReqValidateSuccess(t *testing.T) {
+	// Arrange
+	params := yelp.BusinessSearchReq{
+		Latitude:   43.64784,
+		Longitude:  -79.38872,
+		Radius:     40000,
+		Limit:      50,
+		Offset:     950,
+		SortBy:     yelp.SORT_BY_DISTANCE,
+		Price:      yelp.PriceLevels{yelp.PRICE_LEVEL_1, yelp.PRICE_LEVEL_2},
+		Attributes: yelp.Attributes{yelp.ATTRIBUTE_HOT_AND_NEW},
+		Categories: []string{"bars", "french"},
+		OpenNow:    true,
+	}
+
+	// Act
+	err := params.Validate()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestBusinessSearchReqValidateFieldErrors(t *testing.T) {
+	// Arrange
+	params := yelp.BusinessSearchReq{
+		Radius:     40001,
+		Limit:      51,
+		Offset:     990,
+		SortBy:     "hot_and_new",
+		OpenNow:    true,
+		OpenAt:     time.Unix(1588982400, 0),
+		Price:      yelp.PriceLevels{yelp.PRICE_LEVEL_1, 5},
+		Attributes: yelp.Attributes{"hot_and_new "},
+		Categories: []string{"bars,french"},
+	}
+
+	// Act
+	err := params.Validate()
+
+	// Assert
+	var errs yelp.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.True(t, errors.Is(err, yelp.ErrValidation))
+
+	var fields []string
+	for _, fieldErr := range errs {
+		fields = append(fields, fieldErr.Field)
+	}
+	assert.Equal(t, []string{"location", "radius", "limit", "limit", "sort_by", "open_at", "price", "attributes", "categories"}, fields)
+
+	var fieldErr *yelp.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "location", fieldErr.Field)
+}
+
+func TestBusinessSearchReqValidateOffsetPastResults(t *testing.T) {
+	// Arrange
+	params := yelp.BusinessSearchReq{Location: "Toronto", Offset: 1000}
+
+	// Act
+	err := params.Validate()
+
+	// Assert
+	assert.EqualError(t, err, "invalid request: offset: limit + offset must not exceed 1000, got 1020")
+}
+
+func TestBusinessSearchOnPrimeMeridian(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var query url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, `{"businesses": [], "total": 0}`)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessSearch(yelp.BusinessSearchReq{Latitude: 51.4779, Longitude: 0})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "51.4779", query.Get("latitude"))
+	assert.Equal(t, "0", query.Get("longitude"))
+}
+
+func TestBusinessSearchValidatesBeforeDispatch(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	requests := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(200)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	// Act
+	_, err := client.BusinessSearch(yelp.BusinessSearchReq{Term: "restaurant"})
+
+	// Assert
+	assert.EqualError(t, err, "invalid request: location: location is required if latitude and longitude are not provided")
+	assert.Equal(t, 0, requests)
+}
+
+func TestBusinessSearchSerializesTypedParams(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var query url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_SEARCH_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	params := yelp.BusinessSearchReq{
+		Location:   "222 Yonge St. Toronto, ON",
+		SortBy:     yelp.SORT_BY_RATING,
+		Price:      yelp.PriceLevels{yelp.PRICE_LEVEL_1, yelp.PRICE_LEVEL_2, yelp.PRICE_LEVEL_2},
+		Attributes: yelp.Attributes{yelp.ATTRIBUTE_HOT_AND_NEW, yelp.ATTRIBUTE_DEALS},
+		Categories: []string{"bars", "french"},
+	}
+
+	// Act
+	_, err := client.BusinessSearch(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	assert.Equal(t, "rating", query.Get("sort_by"))
+	assert.Equal(t, "1,2", query.Get("price"))
+	assert.Equal(t, "hot_and_new,deals", query.Get("attributes"))
+	assert.Equal(t, "bars,french", query.Get("categories"))
+}
+
+func TestParsePriceLevel(t *testing.T) {
+	// Act
+	level, err := yelp.ParsePriceLevel("$$$")
+	_, invalidErr := yelp.ParsePriceLevel("$$$$$")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, yelp.PRICE_LEVEL_3, level)
+	assert.Equal(t, "$$$", level.String())
+	assert.Error(t, invalidErr)
+}
+
+func TestBusinessSearchSerializesTimeParams(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var query url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_SEARCH_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	toronto := time.FixedZone("EDT", -4*60*60)
+
+	params := yelp.BusinessSearchReq{
+		Location:              "222 Yonge St. Toronto, ON",
+		OpenAt:                time.Date(2020, 5, 8, 19, 0, 0, 0, toronto),
+		DevicePlatform:        yelp.DEVICE_PLATFORM_IOS,
+		ReservationAt:         time.Date(2020, 5, 8, 19, 30, 0, 0, toronto),
+		ReservationCovers:     4,
+		MatchesPartySizeParam: true,
+	}
+
+	// Act
+	_, err := client.BusinessSearch(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	assert.Equal(t, "1588978800", query.Get("open_at"))
+	assert.Equal(t, "ios", query.Get("device_platform"))
+	assert.Equal(t, "2020-05-08", query.Get("reservation_date"))
+	assert.Equal(t, "19:30", query.Get("reservation_time"))
+	assert.Equal(t, "4", query.Get("reservation_covers"))
+	assert.Equal(t, "true", query.Get("matches_party_size_param"))
+}
+
+func TestBusinessSearchReqValidateReservation(t *testing.T) {
+	// Arrange
+	params := yelp.BusinessSearchReq{
+		Location:              "222 Yonge St. Toronto, ON",
+		ReservationCovers:     12,
+		MatchesPartySizeParam: true,
+		DevicePlatform:        "windows",
+	}
+
+	// Act
+	err := params.Validate()
+
+	// Assert
+	var errs yelp.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+
+	var fields []string
+	for _, fieldErr := range errs {
+		fields = append(fields, fieldErr.Field)
+	}
+	assert.Equal(t, []string{"device_platform", "reservation_covers", "reservation_date", "matches_party_size_param"}, fields)
+}
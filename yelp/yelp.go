@@ -1,14 +1,17 @@
 // Package yelp consists of wrapper functions to interface with the Yelp v3 Fusion API.
-// The package supports business, and eventually event & category endpoints.
+// The package supports business, event and category endpoints.
 package yelp
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/naguigui/yelp-fusion/yelp/utility"
-	"io/ioutil"
+	"log/slog"
 	"net/http"
+	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -19,21 +22,89 @@ const (
 	BUSINESS_REVIEWS_ENDPOINT            = "/reviews"
 	BUSINESS_TRANSACTION_SEARCH_ENDPOINT = "/transactions/delivery/search"
 	BUSINESS_AUTOCOMPLETE_ENDPOINT       = "/autocomplete"
+	BUSINESS_MATCH_ENDPOINT              = "/matches"
+	EVENTS_ENDPOINT                      = "/events"
+	EVENTS_FEATURED_ENDPOINT             = "/featured"
+	CATEGORIES_ENDPOINT                  = "/categories"
+)
+
+// Operation names identify the API a request is dispatched to, for example in CacheOptions.TTLs
+const (
+	OPERATION_BUSINESS_SEARCH       = "BusinessSearch"
+	OPERATION_BUSINESS_DETAILS      = "BusinessDetails"
+	OPERATION_BUSINESS_PHONE_SEARCH = "BusinessPhoneSearch"
+	OPERATION_BUSINESS_REVIEWS      = "BusinessReviews"
+	OPERATION_TRANSACTION_SEARCH    = "TransactionSearch"
+	OPERATION_AUTOCOMPLETE          = "Autocomplete"
+	OPERATION_BUSINESS_MATCH        = "BusinessMatch"
+	OPERATION_EVENT_SEARCH          = "EventSearch"
+	OPERATION_EVENT_LOOKUP          = "EventLookup"
+	OPERATION_FEATURED_EVENT        = "FeaturedEvent"
+	OPERATION_CATEGORIES            = "Categories"
+	OPERATION_CATEGORY_DETAILS      = "CategoryDetails"
+)
+
+// Match thresholds accepted by BusinessMatchReq.MatchThreshold
+const (
+	MATCH_THRESHOLD_NONE    = "none"
+	MATCH_THRESHOLD_DEFAULT = "default"
+	MATCH_THRESHOLD_STRICT  = "strict"
+)
+
+// Sort orders and sort keys accepted by EventSearchReq.SortBy and EventSearchReq.SortOn
+const (
+	EVENT_SORT_BY_ASC        = "asc"
+	EVENT_SORT_BY_DESC       = "desc"
+	EVENT_SORT_ON_POPULARITY = "popularity"
+	EVENT_SORT_ON_TIME_START = "time_start"
 )
 
 // Client is responsible for dispatching requests to the Yelp Fusion API via its methods.
 // An instance is created from Init()
 type Client struct {
-	APIKey     string
-	HTTPClient *http.Client
-	BaseURI    string
+	APIKey      string
+	HTTPClient  *http.Client
+	BaseURI     string
+	RetryPolicy *RetryPolicy  // Retry policy applied to every request. Requests are not retried when nil
+	RateLimiter *RateLimiter  // Limiter shared by every request, including retries. Requests are not limited when nil
+	Cache       *CacheOptions // Response cache consulted before dispatching requests. Responses are not cached when nil
+
+	// CoalesceRequests makes concurrent identical requests share a single upstream call and its result
+	CoalesceRequests bool
+
+	mu            sync.Mutex
+	lastRateLimit RateLimitInfo
+	rateLimitSeen bool
+	cacheHits     atomic.Uint64
+	cacheMisses   atomic.Uint64
+	flights       flightGroup
+	middlewares   []Middleware
+	hooks         []CallHooks
+	logger        *slog.Logger
+	logLevels     LogLevels
 }
 
 // ClientOptions is provided as an argument to create an instance of the Client.
 // It provides the Yelp API Key needed to authenticate API requests
 type ClientOptions struct {
-	APIKey     string
-	HTTPClient *http.Client
+	APIKey      string
+	HTTPClient  *http.Client
+	RetryPolicy *RetryPolicy  // Optional. Retries requests failing with 429, 5xx or network errors. See DefaultRetryPolicy
+	RateLimiter *RateLimiter  // Optional. Client side limiter for Yelp's QPS and daily quota. See NewRateLimiter
+	Cache       *CacheOptions // Optional. Caches successful responses, for example of BusinessDetails
+
+	// Optional. When true, concurrent identical requests share a single upstream call and its result or error
+	CoalesceRequests bool
+
+	// Optional. Middlewares wrapping the HTTP client, the first being the outermost. See Client.Use
+	Middlewares []Middleware
+
+	// Optional. Hooks observing every call, for example to record OpenTelemetry spans and metrics. See the otelyelp package
+	CallHooks []CallHooks
+
+	// Optional. Logs every call with its endpoint, sanitized query, status, duration and attempts. Headers are never logged
+	Logger    *slog.Logger
+	LogLevels *LogLevels // Optional. Levels calls are logged at. See LogLevels for the defaults
 }
 
 // Init creates a new Yelp Client to interface with Yelp API.
@@ -46,19 +117,40 @@ func Init(c *ClientOptions) (*Client, error) {
 		c.HTTPClient = http.DefaultClient
 	}
 
-	return &Client{APIKey: c.APIKey, BaseURI: BASE_URI, HTTPClient: c.HTTPClient}, nil
+	return &Client{
+		APIKey:           c.APIKey,
+		BaseURI:          BASE_URI,
+		HTTPClient:       c.HTTPClient,
+		RetryPolicy:      c.RetryPolicy,
+		RateLimiter:      c.RateLimiter,
+		Cache:            c.Cache,
+		CoalesceRequests: c.CoalesceRequests,
+		middlewares:      append([]Middleware(nil), c.Middlewares...),
+		hooks:            append([]CallHooks(nil), c.CallHooks...),
+		logger:           c.Logger,
+		logLevels:        c.LogLevels.withDefaults(),
+	}, nil
 
 }
 
 // BusinessSearch dispatches a request to the Yelp Business Search API.
 func (c *Client) BusinessSearch(b BusinessSearchReq) (res BusinessSearchRes, err error) {
-	params, err := utility.StructToMap(b)
+	return c.BusinessSearchWithContext(context.Background(), b)
+}
+
+// BusinessSearchWithContext dispatches a request to the Yelp Business Search API using the provided context.
+func (c *Client) BusinessSearchWithContext(ctx context.Context, b BusinessSearchReq) (res BusinessSearchRes, err error) {
+	if err = b.Validate(); err != nil {
+		return BusinessSearchRes{}, err
+	}
+
+	params, err := b.params()
 
 	if err != nil {
 		return BusinessSearchRes{}, fmt.Errorf("unable to process business params: %v", err)
 	}
 
-	if err = c.dispatchRequest(fmt.Sprintf("%s%s", BUSINESS_ENDPOINT, BUSINESS_SEARCH_ENDPOINT), params, &res); err != nil {
+	if err = c.dispatchRequest(ctx, OPERATION_BUSINESS_SEARCH, fmt.Sprintf("%s%s", BUSINESS_ENDPOINT, BUSINESS_SEARCH_ENDPOINT), params, &res); err != nil {
 		return BusinessSearchRes{}, err
 	}
 
@@ -67,6 +159,11 @@ func (c *Client) BusinessSearch(b BusinessSearchReq) (res BusinessSearchRes, err
 
 // BusinessDetails dispatches a request to the Yelp Business Detail API.
 func (c *Client) BusinessDetails(id string, locale string) (res BusinessDetailsRes, err error) {
+	return c.BusinessDetailsWithContext(context.Background(), id, locale)
+}
+
+// BusinessDetailsWithContext dispatches a request to the Yelp Business Detail API using the provided context.
+func (c *Client) BusinessDetailsWithContext(ctx context.Context, id string, locale string) (res BusinessDetailsRes, err error) {
 	if id == "" {
 		return BusinessDetailsRes{}, errors.New("id is required")
 	}
@@ -77,7 +174,7 @@ func (c *Client) BusinessDetails(id string, locale string) (res BusinessDetailsR
 		params["locale"] = locale
 	}
 
-	if err = c.dispatchRequest(fmt.Sprintf("%s/%s", BUSINESS_ENDPOINT, id), params, &res); err != nil {
+	if err = c.dispatchRequest(ctx, OPERATION_BUSINESS_DETAILS, fmt.Sprintf("%s/%s", BUSINESS_ENDPOINT, id), params, &res); err != nil {
 		return BusinessDetailsRes{}, err
 	}
 	return res, nil
@@ -85,6 +182,11 @@ func (c *Client) BusinessDetails(id string, locale string) (res BusinessDetailsR
 
 // BusinessPhoneSearch dispatches a request to the Yelp Phone Search API.
 func (c *Client) BusinessPhoneSearch(phoneNumber string, locale string) (res BusinessPhoneSearchRes, err error) {
+	return c.BusinessPhoneSearchWithContext(context.Background(), phoneNumber, locale)
+}
+
+// BusinessPhoneSearchWithContext dispatches a request to the Yelp Phone Search API using the provided context.
+func (c *Client) BusinessPhoneSearchWithContext(ctx context.Context, phoneNumber string, locale string) (res BusinessPhoneSearchRes, err error) {
 	if phoneNumber == "" {
 		return BusinessPhoneSearchRes{}, errors.New("phone number is required")
 	}
@@ -97,7 +199,7 @@ func (c *Client) BusinessPhoneSearch(phoneNumber string, locale string) (res Bus
 		params["locale"] = locale
 	}
 
-	if err = c.dispatchRequest(fmt.Sprintf("%s%s", BUSINESS_ENDPOINT, BUSINESS_SEARCH_PHONE_ENDPOINT), params, &res); err != nil {
+	if err = c.dispatchRequest(ctx, OPERATION_BUSINESS_PHONE_SEARCH, fmt.Sprintf("%s%s", BUSINESS_ENDPOINT, BUSINESS_SEARCH_PHONE_ENDPOINT), params, &res); err != nil {
 		return BusinessPhoneSearchRes{}, err
 	}
 
@@ -106,6 +208,11 @@ func (c *Client) BusinessPhoneSearch(phoneNumber string, locale string) (res Bus
 
 // BusinessReviews dispatches a request to the Yelp Business Reviews API.
 func (c *Client) BusinessReviews(id string, locale string) (res BusinessReviewsRes, err error) {
+	return c.BusinessReviewsWithContext(context.Background(), id, locale)
+}
+
+// BusinessReviewsWithContext dispatches a request to the Yelp Business Reviews API using the provided context.
+func (c *Client) BusinessReviewsWithContext(ctx context.Context, id string, locale string) (res BusinessReviewsRes, err error) {
 	if id == "" {
 		return BusinessReviewsRes{}, errors.New("business id is required")
 	}
@@ -116,7 +223,7 @@ func (c *Client) BusinessReviews(id string, locale string) (res BusinessReviewsR
 		params["locale"] = locale
 	}
 
-	if err = c.dispatchRequest(fmt.Sprintf("%s/%s%s", BUSINESS_ENDPOINT, id, BUSINESS_REVIEWS_ENDPOINT), params, &res); err != nil {
+	if err = c.dispatchRequest(ctx, OPERATION_BUSINESS_REVIEWS, fmt.Sprintf("%s/%s%s", BUSINESS_ENDPOINT, id, BUSINESS_REVIEWS_ENDPOINT), params, &res); err != nil {
 		return BusinessReviewsRes{}, err
 	}
 	return res, nil
@@ -125,6 +232,11 @@ func (c *Client) BusinessReviews(id string, locale string) (res BusinessReviewsR
 // TransactionSearch dispatches a request to the Yelp Business Transaction Search API.
 // Default value for transaction type is delivery.
 func (c *Client) TransactionSearch(b BusinessTransactionReq) (res BusinessTransactionSearchRes, err error) {
+	return c.TransactionSearchWithContext(context.Background(), b)
+}
+
+// TransactionSearchWithContext dispatches a request to the Yelp Business Transaction Search API using the provided context.
+func (c *Client) TransactionSearchWithContext(ctx context.Context, b BusinessTransactionReq) (res BusinessTransactionSearchRes, err error) {
 	params := make(map[string]interface{})
 
 	// Use location if specified, otherwise use latitude/longitude
@@ -138,7 +250,7 @@ func (c *Client) TransactionSearch(b BusinessTransactionReq) (res BusinessTransa
 		params["longitude"] = b.Longitude
 	}
 
-	if err = c.dispatchRequest(BUSINESS_TRANSACTION_SEARCH_ENDPOINT, params, &res); err != nil {
+	if err = c.dispatchRequest(ctx, OPERATION_TRANSACTION_SEARCH, BUSINESS_TRANSACTION_SEARCH_ENDPOINT, params, &res); err != nil {
 		return BusinessTransactionSearchRes{}, err
 	}
 
@@ -147,6 +259,11 @@ func (c *Client) TransactionSearch(b BusinessTransactionReq) (res BusinessTransa
 
 // Autocomplete dispatches a request to the Yelp Autocomplete API.
 func (c *Client) Autocomplete(b BusinessAutocompleteReq) (res BusinessAutocompleteRes, err error) {
+	return c.AutocompleteWithContext(context.Background(), b)
+}
+
+// AutocompleteWithContext dispatches a request to the Yelp Autocomplete API using the provided context.
+func (c *Client) AutocompleteWithContext(ctx context.Context, b BusinessAutocompleteReq) (res BusinessAutocompleteRes, err error) {
 	params := make(map[string]interface{})
 
 	if b.Text == "" {
@@ -169,16 +286,226 @@ func (c *Client) Autocomplete(b BusinessAutocompleteReq) (res BusinessAutocomple
 		params["locale"] = b.Locale
 	}
 
-	if err = c.dispatchRequest(BUSINESS_AUTOCOMPLETE_ENDPOINT, params, &res); err != nil {
+	if err = c.dispatchRequest(ctx, OPERATION_AUTOCOMPLETE, BUSINESS_AUTOCOMPLETE_ENDPOINT, params, &res); err != nil {
 		return BusinessAutocompleteRes{}, err
 	}
 
 	return res, nil
 }
 
+// BusinessMatch dispatches a request to the Yelp Business Match API.
+func (c *Client) BusinessMatch(b BusinessMatchReq) (res BusinessMatchRes, err error) {
+	return c.BusinessMatchWithContext(context.Background(), b)
+}
+
+// BusinessMatchWithContext dispatches a request to the Yelp Business Match API using the provided context.
+func (c *Client) BusinessMatchWithContext(ctx context.Context, b BusinessMatchReq) (res BusinessMatchRes, err error) {
+	if b.Name == "" {
+		return BusinessMatchRes{}, errors.New("name is required")
+	}
+
+	if b.Address1 == "" {
+		return BusinessMatchRes{}, errors.New("address1 is required")
+	}
+
+	if b.City == "" {
+		return BusinessMatchRes{}, errors.New("city is required")
+	}
+
+	if b.State == "" {
+		return BusinessMatchRes{}, errors.New("state is required")
+	}
+
+	if b.Country == "" {
+		return BusinessMatchRes{}, errors.New("country is required")
+	}
+
+	if b.Limit < 0 || b.Limit > 10 {
+		return BusinessMatchRes{}, fmt.Errorf("limit must be between 1 and 10, or 0 for the default of 3, got %d", b.Limit)
+	}
+
+	if b.Latitude < -90 || b.Latitude > 90 {
+		return BusinessMatchRes{}, fmt.Errorf("latitude must be between -90 and 90, got %v", b.Latitude)
+	}
+
+	if b.Longitude < -180 || b.Longitude > 180 {
+		return BusinessMatchRes{}, fmt.Errorf("longitude must be between -180 and 180, got %v", b.Longitude)
+	}
+
+	switch b.MatchThreshold {
+	case "", MATCH_THRESHOLD_NONE, MATCH_THRESHOLD_DEFAULT, MATCH_THRESHOLD_STRICT:
+	default:
+		return BusinessMatchRes{}, fmt.Errorf("match threshold must be one of none, default or strict, got %q", b.MatchThreshold)
+	}
+
+	params, err := utility.StructToMap(b)
+
+	if err != nil {
+		return BusinessMatchRes{}, fmt.Errorf("unable to process business match params: %v", err)
+	}
+
+	if err = c.dispatchRequest(ctx, OPERATION_BUSINESS_MATCH, fmt.Sprintf("%s%s", BUSINESS_ENDPOINT, BUSINESS_MATCH_ENDPOINT), params, &res); err != nil {
+		return BusinessMatchRes{}, err
+	}
+
+	return res, nil
+}
+
+// EventSearch dispatches a request to the Yelp Event Search API.
+func (c *Client) EventSearch(b EventSearchReq) (res EventSearchRes, err error) {
+	return c.EventSearchWithContext(context.Background(), b)
+}
+
+// EventSearchWithContext dispatches a request to the Yelp Event Search API using the provided context.
+func (c *Client) EventSearchWithContext(ctx context.Context, b EventSearchReq) (res EventSearchRes, err error) {
+	if (b.Latitude == 0) != (b.Longitude == 0) {
+		return EventSearchRes{}, errors.New("latitude and longitude must be provided together")
+	}
+
+	if b.Limit < 0 || b.Limit > EVENT_SEARCH_MAX_LIMIT {
+		return EventSearchRes{}, fmt.Errorf("limit must be between 1 and %d", EVENT_SEARCH_MAX_LIMIT)
+	}
+
+	if b.Radius < 0 || b.Radius > EVENT_SEARCH_MAX_RADIUS {
+		return EventSearchRes{}, fmt.Errorf("radius must be between 0 and %d meters", EVENT_SEARCH_MAX_RADIUS)
+	}
+
+	if b.SortBy != "" && b.SortBy != EVENT_SORT_BY_ASC && b.SortBy != EVENT_SORT_BY_DESC {
+		return EventSearchRes{}, fmt.Errorf("sort_by must be %s or %s, got %q", EVENT_SORT_BY_ASC, EVENT_SORT_BY_DESC, b.SortBy)
+	}
+
+	if b.SortOn != "" && b.SortOn != EVENT_SORT_ON_POPULARITY && b.SortOn != EVENT_SORT_ON_TIME_START {
+		return EventSearchRes{}, fmt.Errorf("sort_on must be %s or %s, got %q", EVENT_SORT_ON_POPULARITY, EVENT_SORT_ON_TIME_START, b.SortOn)
+	}
+
+	params, err := utility.StructToMap(b)
+
+	if err != nil {
+		return EventSearchRes{}, fmt.Errorf("unable to process event params: %v", err)
+	}
+
+	if err = c.dispatchRequest(ctx, OPERATION_EVENT_SEARCH, EVENTS_ENDPOINT, params, &res); err != nil {
+		return EventSearchRes{}, err
+	}
+
+	return res, nil
+}
+
+// EventLookup dispatches a request to the Yelp Event Lookup API.
+func (c *Client) EventLookup(id string, locale string) (res EventDetailsRes, err error) {
+	return c.EventLookupWithContext(context.Background(), id, locale)
+}
+
+// EventLookupWithContext dispatches a request to the Yelp Event Lookup API using the provided context.
+func (c *Client) EventLookupWithContext(ctx context.Context, id string, locale string) (res EventDetailsRes, err error) {
+	if id == "" {
+		return EventDetailsRes{}, errors.New("event id is required")
+	}
+
+	params := make(map[string]interface{})
+
+	if locale != "" {
+		params["locale"] = locale
+	}
+
+	if err = c.dispatchRequest(ctx, OPERATION_EVENT_LOOKUP, fmt.Sprintf("%s/%s", EVENTS_ENDPOINT, id), params, &res); err != nil {
+		return EventDetailsRes{}, err
+	}
+
+	return res, nil
+}
+
+// FeaturedEvent dispatches a request to the Yelp Featured Event API.
+func (c *Client) FeaturedEvent(b FeaturedEventReq) (res EventDetailsRes, err error) {
+	return c.FeaturedEventWithContext(context.Background(), b)
+}
+
+// FeaturedEventWithContext dispatches a request to the Yelp Featured Event API using the provided context.
+func (c *Client) FeaturedEventWithContext(ctx context.Context, b FeaturedEventReq) (res EventDetailsRes, err error) {
+	if b.Location == "" && (b.Latitude == 0 || b.Longitude == 0) {
+		return EventDetailsRes{}, errors.New("latitude and longitude is required if location is not specified")
+	}
+
+	params, err := utility.StructToMap(b)
+
+	if err != nil {
+		return EventDetailsRes{}, fmt.Errorf("unable to process featured event params: %v", err)
+	}
+
+	if err = c.dispatchRequest(ctx, OPERATION_FEATURED_EVENT, fmt.Sprintf("%s%s", EVENTS_ENDPOINT, EVENTS_FEATURED_ENDPOINT), params, &res); err != nil {
+		return EventDetailsRes{}, err
+	}
+
+	return res, nil
+}
+
+// Categories dispatches a request to the Yelp All Categories API.
+func (c *Client) Categories(locale string) (res CategoriesRes, err error) {
+	return c.CategoriesWithContext(context.Background(), locale)
+}
+
+// CategoriesWithContext dispatches a request to the Yelp All Categories API using the provided context.
+func (c *Client) CategoriesWithContext(ctx context.Context, locale string) (res CategoriesRes, err error) {
+	params := make(map[string]interface{})
+
+	if locale != "" {
+		params["locale"] = locale
+	}
+
+	if err = c.dispatchRequest(ctx, OPERATION_CATEGORIES, CATEGORIES_ENDPOINT, params, &res); err != nil {
+		return CategoriesRes{}, err
+	}
+
+	return res, nil
+}
+
+// CategoryDetails dispatches a request to the Yelp Category Details API.
+func (c *Client) CategoryDetails(alias string, locale string) (res CategoryDetailsRes, err error) {
+	return c.CategoryDetailsWithContext(context.Background(), alias, locale)
+}
+
+// CategoryDetailsWithContext dispatches a request to the Yelp Category Details API using the provided context.
+func (c *Client) CategoryDetailsWithContext(ctx context.Context, alias string, locale string) (res CategoryDetailsRes, err error) {
+	if alias == "" {
+		return CategoryDetailsRes{}, errors.New("category alias is required")
+	}
+
+	params := make(map[string]interface{})
+
+	if locale != "" {
+		params["locale"] = locale
+	}
+
+	if err = c.dispatchRequest(ctx, OPERATION_CATEGORY_DETAILS, fmt.Sprintf("%s/%s", CATEGORIES_ENDPOINT, alias), params, &res); err != nil {
+		return CategoryDetailsRes{}, err
+	}
+
+	return res, nil
+}
+
+// CategoryTree fetches every category from the Yelp All Categories API and indexes them in a CategoryTree.
+func (c *Client) CategoryTree(locale string) (*CategoryTree, error) {
+	return c.CategoryTreeWithContext(context.Background(), locale)
+}
+
+// CategoryTreeWithContext fetches every category using the provided context and indexes them in a CategoryTree.
+func (c *Client) CategoryTreeWithContext(ctx context.Context, locale string) (*CategoryTree, error) {
+	res, err := c.CategoriesWithContext(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCategoryTree(res.Categories), nil
+}
+
 // dispatchRequest formats request and dispatches it to Yelp API.
-func (c *Client) dispatchRequest(endpoint string, params map[string]interface{}, payload interface{}) error {
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", c.BaseURI, endpoint), nil)
+// Responses of operations with a cache TTL are served from and stored in the Client cache.
+func (c *Client) dispatchRequest(ctx context.Context, operation string, endpoint string, params map[string]interface{}, payload interface{}) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.BaseURI, endpoint), nil)
+	if err != nil {
+		return err
+	}
+
 	q := req.URL.Query()
 
 	for key, val := range params {
@@ -186,20 +513,41 @@ func (c *Client) dispatchRequest(endpoint string, params map[string]interface{},
 	}
 
 	req.URL.RawQuery = q.Encode()
+
+	ctx, finish := c.startCall(ctx, operation, req)
+	defer func() { finish(err) }()
+
+	ctx = context.WithValue(ctx, operationKey{}, operation)
+	req = req.WithContext(ctx)
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
-	res, err := c.HTTPClient.Do(req)
+
+	// The query is encoded with sorted keys, so equivalent params share a cache and coalescing key
+	key := fmt.Sprintf("%s?%s", endpoint, req.URL.RawQuery)
+	ttl := c.Cache.ttl(operation)
+
+	if ttl > 0 {
+		if data, ok := c.cacheGet(key); ok {
+			if m, ok := payload.(metaSetter); ok {
+				m.setMeta(ResponseMeta{CacheHit: true})
+			}
+
+			markCacheHit(ctx)
+			return json.Unmarshal(data, &payload)
+		}
+	}
+
+	res, data, err := c.sendCoalesced(req, key)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return errors.New(res.Status)
+		return newAPIError(res, data)
 	}
 
-	data, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return err
+	if m, ok := payload.(metaSetter); ok {
+		info, _ := parseRateLimitInfo(res.Header)
+		m.setMeta(ResponseMeta{RateLimit: info})
 	}
 
 	err = json.Unmarshal(data, &payload)
@@ -207,5 +555,9 @@ func (c *Client) dispatchRequest(endpoint string, params map[string]interface{},
 		return err
 	}
 
+	if ttl > 0 {
+		c.Cache.Cache.Set(key, data, ttl)
+	}
+
 	return err
 }
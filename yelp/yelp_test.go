@@ -1,12 +1,16 @@
 package yelp_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/naguigui/yelp-fusion/yelp"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 )
 
 var (
@@ -449,3 +453,213 @@ func TestAutocompleteError(t *testing.T) {
 	// Assert
 	assert.Error(t, err, "500 Internal Server Error")
 }
+
+func TestBusinessSearchWithContextCancelled(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	params := yelp.BusinessSearchReq{
+		Term:     "restaurant",
+		Location: "222 Yonge St. Toronto, ON",
+	}
+
+	// Act
+	_, err := client.BusinessSearchWithContext(ctx, params)
+
+	// Assert
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRateLimitInfo(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("RateLimit-DailyLimit", "5000")
+		w.Header().Set("RateLimit-Remaining", "4321")
+		w.Header().Set("RateLimit-ResetTime", "2020-05-05T00:00:00+00:00")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_REVIEWS_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	client.BaseURI = ts.URL
+
+	expected := yelp.RateLimitInfo{
+		DailyLimit: 5000,
+		Remaining:  4321,
+		ResetTime:  time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC),
+	}
+
+	_, seenBefore := client.LastRateLimit()
+
+	// Act
+	res, err := client.BusinessReviews("review12345", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last, seen := client.LastRateLimit()
+
+	// Assert
+	assert.False(t, seenBefore)
+	assert.True(t, seen)
+	assert.Equal(t, expected.DailyLimit, res.RateLimit.DailyLimit)
+	assert.Equal(t, expected.Remaining, res.RateLimit.Remaining)
+	assert.True(t, expected.ResetTime.Equal(res.RateLimit.ResetTime))
+	assert.Equal(t, res.RateLimit, last)
+}
+
+const BUSINESS_MATCH_RESPONSE = `
+{
+	"businesses": [
+	  {
+		"id": "WavvLdfdP6g8aZTtbBQHTw",
+		"alias": "gary-danko-san-francisco",
+		"name": "Gary Danko",
+		"location": {
+		  "address1": "800 N Point St",
+		  "address2": "",
+		  "address3": "",
+		  "city": "San Francisco",
+		  "zip_code": "94109",
+		  "country": "US",
+		  "state": "CA",
+		  "display_address": ["800 N Point St", "San Francisco, CA 94109"]
+		},
+		"coordinates": {
+		  "latitude": 37.80587,
+		  "longitude": -122.42058
+		},
+		"phone": "+14157492060"
+	  }
+	]
+}
+`
+
+func TestBusinessMatchSuccess(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	var query url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		fmt.Fprint(w, BUSINESS_MATCH_RESPONSE)
+	}))
+
+	defer ts.Close()
+
+	expected := yelp.BusinessMatchRes{
+		Businesses: []yelp.BusinessMatch{{
+			ID:    "WavvLdfdP6g8aZTtbBQHTw",
+			Alias: "gary-danko-san-francisco",
+			Name:  "Gary Danko",
+			Location: yelp.LocationBusinessDetails{
+				Location: yelp.Location{
+					City:     "San Francisco",
+					Country:  "US",
+					State:    "CA",
+					Address1: "800 N Point St",
+					ZipCode:  "94109",
+				},
+				DisplayAddress: []string{"800 N Point St", "San Francisco, CA 94109"},
+			},
+			Coordinates: yelp.Coordinates{
+				Latitude:  37.80587,
+				Longitude: -122.42058,
+			},
+			Phone: "+14157492060",
+		}},
+	}
+
+	client.BaseURI = ts.URL
+
+	params := yelp.BusinessMatchReq{
+		Name:           "Gary Danko",
+		Address1:       "800 N Point St",
+		City:           "San Francisco",
+		State:          "CA",
+		Country:        "US",
+		PostalCode:     "94109",
+		MatchThreshold: yelp.MATCH_THRESHOLD_STRICT,
+	}
+
+	// Act
+	res, err := client.BusinessMatch(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	assert.Equal(t, res, expected)
+	assert.Equal(t, "Gary Danko", query.Get("name"))
+	assert.Equal(t, "strict", query.Get("match_threshold"))
+	assert.Equal(t, "94109", query.Get("postal_code"))
+	assert.False(t, query.Has("zip_code"))
+	assert.False(t, query.Has("phone"))
+}
+
+func TestBusinessMatchValidation(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	params := yelp.BusinessMatchReq{
+		Name:     "Gary Danko",
+		Address1: "800 N Point St",
+		City:     "San Francisco",
+		Country:  "US",
+	}
+
+	// Act
+	_, err := client.BusinessMatch(params)
+
+	// Assert
+	assert.EqualError(t, err, "state is required")
+}
+
+func TestBusinessMatchValidatesLimitAndCoordinates(t *testing.T) {
+	// Arrange
+	client := setup()
+
+	params := yelp.BusinessMatchReq{
+		Name:     "Gary Danko",
+		Address1: "800 N Point St",
+		City:     "San Francisco",
+		State:    "CA",
+		Country:  "US",
+	}
+
+	limit, latitude, longitude := params, params, params
+	limit.Limit = 11
+	latitude.Latitude = 91
+	longitude.Longitude = -181
+
+	// Act
+	_, limitErr := client.BusinessMatch(limit)
+	_, latitudeErr := client.BusinessMatch(latitude)
+	_, longitudeErr := client.BusinessMatch(longitude)
+
+	// Assert
+	assert.EqualError(t, limitErr, "limit must be between 1 and 10, or 0 for the default of 3, got 11")
+	assert.EqualError(t, latitudeErr, "latitude must be between -90 and 90, got 91")
+	assert.EqualError(t, longitudeErr, "longitude must be between -180 and 180, got -181")
+}
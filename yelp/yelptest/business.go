@@ -0,0 +1,489 @@
+package yelptest
+
+import (
+	"github.com/naguigui/yelp-fusion/yelp"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	BUSINESS_SEARCH_DEFAULT_RADIUS = 40000 // Radius in meters of searches by coordinates without a radius
+	AUTOCOMPLETE_MAX_SUGGESTIONS   = 3     // Maximum number of terms, businesses and categories suggested by Autocomplete
+	BUSINESS_REVIEWS_MAX           = 3     // Maximum number of reviews returned by Business Reviews
+	BUSINESS_MATCH_DEFAULT_LIMIT   = 3     // Number of businesses returned by Business Match without a limit
+)
+
+// searchArea is the location or coordinates a search is restricted to.
+type searchArea struct {
+	location  string
+	latitude  float64
+	longitude float64
+	radius    float64
+	byCoords  bool
+}
+
+// parseSearchArea reads the location, latitude, longitude and radius params, writing a Yelp error and returning false if they are invalid.
+func (s *Server) parseSearchArea(w http.ResponseWriter, q url.Values) (searchArea, bool) {
+	area := searchArea{location: strings.ToLower(strings.TrimSpace(q.Get("location"))), radius: BUSINESS_SEARCH_DEFAULT_RADIUS}
+
+	if q.Get("latitude") != "" && q.Get("longitude") != "" {
+		lat, latErr := strconv.ParseFloat(q.Get("latitude"), 64)
+		lng, lngErr := strconv.ParseFloat(q.Get("longitude"), 64)
+		if latErr != nil || lngErr != nil {
+			validationError(w, "latitude", "Latitude and longitude must be decimal numbers")
+			return area, false
+		}
+
+		area.latitude, area.longitude, area.byCoords = lat, lng, true
+	}
+
+	if area.location == "" && !area.byCoords {
+		validationError(w, "location", "Please specify a location or a latitude and longitude")
+		return area, false
+	}
+
+	if v := q.Get("radius"); v != "" {
+		radius, err := strconv.Atoi(v)
+		if err != nil || radius < 0 || radius > BUSINESS_SEARCH_DEFAULT_RADIUS {
+			validationError(w, "radius", "radius must be an integer between 0 and 40000")
+			return area, false
+		}
+		area.radius = float64(radius)
+	}
+
+	if area.location != "" && !area.byCoords && !s.knownLocation(area.location) {
+		writeError(w, http.StatusBadRequest, "LOCATION_NOT_FOUND", "Could not execute search, try specifying a more exact location.")
+		return area, false
+	}
+
+	return area, true
+}
+
+// knownLocation reports whether any seeded business is in the location, the way Yelp fails to geocode unknown places.
+func (s *Server) knownLocation(location string) bool {
+	for _, b := range s.businesses {
+		if inLocation(b.Location.Location, location) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains reports whether the business is in the area, returning its distance in meters when searching by coordinates.
+func (a searchArea) contains(b yelp.BusinessDetailsRes) (float32, bool) {
+	if a.byCoords {
+		d := haversine(a.latitude, a.longitude, float64(b.Coordinates.Latitude), float64(b.Coordinates.Longitude))
+		return float32(d), d <= a.radius
+	}
+
+	return 0, inLocation(b.Location.Location, a.location)
+}
+
+// inLocation reports whether the lowercase location names the city, zip code or address of l.
+func inLocation(l yelp.Location, location string) bool {
+	for _, part := range []string{l.City, l.ZipCode, l.Address1} {
+		if part != "" && strings.Contains(location, strings.ToLower(part)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// haversine returns the distance in meters between two coordinates.
+func haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadius = 6371000
+
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// toBusiness converts seeded business details to the summary returned by searches.
+func toBusiness(d yelp.BusinessDetailsRes) yelp.Business {
+	return yelp.Business{
+		ID:           d.ID,
+		Rating:       d.Rating,
+		Price:        d.Price,
+		Phone:        d.Phone,
+		Alias:        d.Alias,
+		IsClosed:     d.IsClosed,
+		Categories:   d.Categories,
+		ReviewCount:  d.ReviewCount,
+		Name:         d.Name,
+		URL:          d.URL,
+		Coordinates:  d.Coordinates,
+		ImageURL:     d.ImageURL,
+		Location:     d.Location.Location,
+		Transactions: d.Transactions,
+	}
+}
+
+// matchesTerm reports whether the business name, alias or one of its categories contains the lowercase term.
+func matchesTerm(b yelp.BusinessDetailsRes, term string) bool {
+	if strings.Contains(strings.ToLower(b.Name), term) || strings.Contains(b.Alias, term) {
+		return true
+	}
+
+	for _, c := range b.Categories {
+		if strings.Contains(c.Alias, term) || strings.Contains(strings.ToLower(c.Title), term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasCategory reports whether the business belongs to one of the category aliases.
+func hasCategory(b yelp.BusinessDetailsRes, aliases []string) bool {
+	for _, c := range b.Categories {
+		for _, alias := range aliases {
+			if c.Alias == alias {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasTransaction reports whether the business is registered for the transaction, for example "delivery".
+func hasTransaction(b yelp.BusinessDetailsRes, transaction string) bool {
+	for _, t := range b.Transactions {
+		if t == transaction {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitList splits a comma delimited param, ignoring empty items.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// parseLimit reads the limit and offset params, writing a validation error and returning false if they are out of bounds.
+func parseLimit(w http.ResponseWriter, q url.Values, defaultLimit int, maxLimit int, maxResults int) (int, int, bool) {
+	limit, offset := defaultLimit, 0
+
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 0 || l > maxLimit {
+			validationError(w, "limit", "limit must be an integer between 0 and "+strconv.Itoa(maxLimit))
+			return 0, 0, false
+		}
+		limit = l
+	}
+
+	if v := q.Get("offset"); v != "" {
+		o, err := strconv.Atoi(v)
+		if err != nil || o < 0 {
+			validationError(w, "offset", "offset must be a positive integer")
+			return 0, 0, false
+		}
+		offset = o
+	}
+
+	if maxResults > 0 && limit+offset > maxResults {
+		validationError(w, "limit", "Too many results requested, limit+offset must be <= "+strconv.Itoa(maxResults))
+		return 0, 0, false
+	}
+
+	return limit, offset, true
+}
+
+// page returns the items of the page starting at offset.
+func page[T any](items []T, offset int, limit int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[offset:end]
+}
+
+// businessSearch serves the Business Search API. It filters on term, location or coordinates and radius, categories and price,
+// sorts by rating, review count or distance and paginates. Other filters, such as attributes or open_now, are ignored.
+func (s *Server) businessSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	area, ok := s.parseSearchArea(w, q)
+	if !ok {
+		return
+	}
+
+	limit, offset, ok := parseLimit(w, q, yelp.BUSINESS_SEARCH_DEFAULT_LIMIT, yelp.BUSINESS_SEARCH_MAX_LIMIT, yelp.BUSINESS_SEARCH_MAX_RESULTS)
+	if !ok {
+		return
+	}
+
+	sortBy := yelp.SortBy(q.Get("sort_by"))
+	if sortBy != "" && !sortBy.Valid() {
+		validationError(w, "sort_by", "sort_by must be one of best_match, rating, review_count or distance")
+		return
+	}
+
+	prices := make(map[int]bool)
+	for _, p := range splitList(q.Get("price")) {
+		level, err := strconv.Atoi(p)
+		if err != nil || !yelp.PriceLevel(level).Valid() {
+			validationError(w, "price", "price must be a comma delimited list of integers between 1 and 4")
+			return
+		}
+		prices[level] = true
+	}
+
+	term := strings.ToLower(strings.TrimSpace(q.Get("term")))
+	categories := splitList(q.Get("categories"))
+
+	matches := []yelp.Business{}
+	for _, b := range s.businesses {
+		distance, ok := area.contains(b)
+		if !ok || (term != "" && !matchesTerm(b, term)) || (len(categories) > 0 && !hasCategory(b, categories)) || (len(prices) > 0 && !prices[len(b.Price)]) {
+			continue
+		}
+
+		business := toBusiness(b)
+		business.Distance = distance
+		matches = append(matches, business)
+	}
+
+	switch sortBy {
+	case yelp.SORT_BY_RATING:
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].Rating > matches[j].Rating })
+	case yelp.SORT_BY_REVIEW_COUNT:
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].ReviewCount > matches[j].ReviewCount })
+	case yelp.SORT_BY_DISTANCE:
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].Distance < matches[j].Distance })
+	}
+
+	writeJSON(w, yelp.BusinessSearchRes{
+		Region:     region(area, matches),
+		Total:      len(matches),
+		Businesses: page(matches, offset, limit),
+	})
+}
+
+// region returns the center of the searched coordinates, or of the businesses found when searching by location.
+func region(area searchArea, businesses []yelp.Business) yelp.Region {
+	if area.byCoords {
+		return yelp.Region{Center: yelp.Center{Latitude: float32(area.latitude), Longitude: float32(area.longitude)}}
+	}
+
+	var center yelp.Center
+	for _, b := range businesses {
+		center.Latitude += b.Coordinates.Latitude / float32(len(businesses))
+		center.Longitude += b.Coordinates.Longitude / float32(len(businesses))
+	}
+
+	return yelp.Region{Center: center}
+}
+
+// phoneSearch serves the Phone Search API.
+func (s *Server) phoneSearch(w http.ResponseWriter, r *http.Request) {
+	phone := r.URL.Query().Get("phone")
+	if !strings.HasPrefix(phone, "+") {
+		validationError(w, "phone", "phone must start with + and include the country code, like +14159083801")
+		return
+	}
+
+	matches := []yelp.Business{}
+	for _, b := range s.businesses {
+		if b.Phone == phone {
+			matches = append(matches, toBusiness(b))
+		}
+	}
+
+	writeJSON(w, yelp.BusinessPhoneSearchRes{Total: len(matches), Businesses: matches})
+}
+
+// transactionSearch serves the Transaction Search API, returning the businesses of the area registered for delivery.
+func (s *Server) transactionSearch(w http.ResponseWriter, r *http.Request) {
+	area, ok := s.parseSearchArea(w, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	matches := []yelp.Business{}
+	for _, b := range s.businesses {
+		if distance, ok := area.contains(b); ok && hasTransaction(b, "delivery") {
+			business := toBusiness(b)
+			business.Distance = distance
+			matches = append(matches, business)
+		}
+	}
+
+	writeJSON(w, yelp.BusinessTransactionSearchRes{Total: len(matches), Businesses: matches})
+}
+
+// autocomplete serves the Autocomplete API, suggesting businesses and categories whose name has a word starting with the text.
+func (s *Server) autocomplete(w http.ResponseWriter, r *http.Request) {
+	text := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("text")))
+	if text == "" {
+		validationError(w, "text", "text is required")
+		return
+	}
+
+	res := yelp.BusinessAutocompleteRes{Terms: []yelp.Term{}, Businesses: []yelp.AutocompleteBusiness{}, Categories: []yelp.Category{}}
+	seenTerms := make(map[string]bool)
+	seenCategories := make(map[string]bool)
+
+	addTerm := func(t string) {
+		if len(res.Terms) < AUTOCOMPLETE_MAX_SUGGESTIONS && !seenTerms[t] {
+			seenTerms[t] = true
+			res.Terms = append(res.Terms, yelp.Term{Text: t})
+		}
+	}
+
+	addCategory := func(c yelp.Category) {
+		if len(res.Categories) < AUTOCOMPLETE_MAX_SUGGESTIONS && !seenCategories[c.Alias] && hasWordPrefix(c.Title, text) {
+			seenCategories[c.Alias] = true
+			res.Categories = append(res.Categories, c)
+			addTerm(c.Title)
+		}
+	}
+
+	for _, c := range s.categories {
+		addCategory(yelp.Category{Alias: c.Alias, Title: c.Title})
+	}
+
+	for _, b := range s.businesses {
+		for _, c := range b.Categories {
+			addCategory(c)
+		}
+
+		if len(res.Businesses) < AUTOCOMPLETE_MAX_SUGGESTIONS && hasWordPrefix(b.Name, text) {
+			res.Businesses = append(res.Businesses, yelp.AutocompleteBusiness{Name: b.Name, ID: b.ID})
+			addTerm(b.Name)
+		}
+	}
+
+	writeJSON(w, res)
+}
+
+// hasWordPrefix reports whether s, or one of its words, starts with the lowercase prefix.
+func hasWordPrefix(s string, prefix string) bool {
+	s = strings.ToLower(s)
+	if strings.HasPrefix(s, prefix) {
+		return true
+	}
+
+	for _, word := range strings.Fields(s) {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// businessMatch serves the Business Match API. Businesses must be in the same city, state and country and postal code when given,
+// have the same name unless match_threshold is none, and the same address1 when it is strict.
+func (s *Server) businessMatch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	for _, field := range []string{"name", "address1", "city", "state", "country"} {
+		if q.Get(field) == "" {
+			validationError(w, field, field+" is required")
+			return
+		}
+	}
+
+	limit, _, ok := parseLimit(w, q, BUSINESS_MATCH_DEFAULT_LIMIT, 10, 0)
+	if !ok {
+		return
+	}
+
+	threshold := q.Get("match_threshold")
+
+	matches := []yelp.BusinessMatch{}
+	for _, b := range s.businesses {
+		l := b.Location
+		if !strings.EqualFold(l.City, q.Get("city")) || !strings.EqualFold(l.State, q.Get("state")) || !strings.EqualFold(l.Country, q.Get("country")) {
+			continue
+		}
+
+		if postalCode := q.Get("postal_code"); postalCode != "" && !strings.EqualFold(strings.ReplaceAll(l.ZipCode, " ", ""), strings.ReplaceAll(postalCode, " ", "")) {
+			continue
+		}
+
+		if threshold != yelp.MATCH_THRESHOLD_NONE && !strings.EqualFold(b.Name, q.Get("name")) {
+			continue
+		}
+
+		if threshold == yelp.MATCH_THRESHOLD_STRICT && !strings.EqualFold(l.Address1, q.Get("address1")) {
+			continue
+		}
+
+		matches = append(matches, yelp.BusinessMatch{
+			ID:          b.ID,
+			Alias:       b.Alias,
+			Name:        b.Name,
+			Location:    b.Location,
+			Coordinates: b.Coordinates,
+			Phone:       b.Phone,
+		})
+	}
+
+	writeJSON(w, yelp.BusinessMatchRes{Businesses: page(matches, 0, limit)})
+}
+
+// findBusiness returns the business with the ID or alias.
+func (s *Server) findBusiness(id string) (yelp.BusinessDetailsRes, bool) {
+	for _, b := range s.businesses {
+		if b.ID == id || b.Alias == id {
+			return b, true
+		}
+	}
+
+	return yelp.BusinessDetailsRes{}, false
+}
+
+// businessDetails serves the Business Details API.
+func (s *Server) businessDetails(w http.ResponseWriter, id string) {
+	b, ok := s.findBusiness(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "BUSINESS_NOT_FOUND", "The requested business could not be found.")
+		return
+	}
+
+	writeJSON(w, b)
+}
+
+// businessReviews serves the Business Reviews API, returning the first three reviews of the business.
+func (s *Server) businessReviews(w http.ResponseWriter, id string) {
+	b, ok := s.findBusiness(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "BUSINESS_NOT_FOUND", "The requested business could not be found.")
+		return
+	}
+
+	reviews := s.reviews[b.ID]
+
+	writeJSON(w, yelp.BusinessReviewsRes{
+		Reviews:           page(append([]yelp.Review{}, reviews...), 0, BUSINESS_REVIEWS_MAX),
+		Total:             len(reviews),
+		PossibleLanguages: []string{"en"},
+	})
+}
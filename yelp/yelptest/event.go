@@ -0,0 +1,181 @@
+package yelptest
+
+import (
+	"github.com/naguigui/yelp-fusion/yelp"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EVENT_SEARCH_DEFAULT_LIMIT is the number of events returned by Event Search without a limit
+const EVENT_SEARCH_DEFAULT_LIMIT = 3
+
+// eventStart returns the start time of the event, or the zero time if it can't be parsed.
+func eventStart(e yelp.Event) time.Time {
+	t, _ := time.Parse(time.RFC3339, e.TimeStart)
+	return t
+}
+
+// eventEnd returns the end time of the event, falling back to its start time when unknown.
+func eventEnd(e yelp.Event) time.Time {
+	if t, err := time.Parse(time.RFC3339, e.TimeEnd); err == nil {
+		return t
+	}
+
+	return eventStart(e)
+}
+
+// eventInArea reports whether the event is in the location, or within radius meters of the coordinates.
+func eventInArea(e yelp.Event, area searchArea) bool {
+	if area.byCoords {
+		return haversine(area.latitude, area.longitude, float64(e.Latitude), float64(e.Longitude)) <= area.radius
+	}
+
+	return area.location == "" || inLocation(e.Location.Location, area.location)
+}
+
+// parseEventArea reads the optional location, coordinates and radius of an event request.
+func parseEventArea(w http.ResponseWriter, r *http.Request) (searchArea, bool) {
+	q := r.URL.Query()
+	area := searchArea{location: strings.ToLower(strings.TrimSpace(q.Get("location"))), radius: BUSINESS_SEARCH_DEFAULT_RADIUS}
+
+	if (q.Get("latitude") == "") != (q.Get("longitude") == "") {
+		validationError(w, "latitude", "latitude and longitude must be provided together")
+		return area, false
+	}
+
+	if q.Get("latitude") != "" {
+		lat, latErr := strconv.ParseFloat(q.Get("latitude"), 64)
+		lng, lngErr := strconv.ParseFloat(q.Get("longitude"), 64)
+		if latErr != nil || lngErr != nil {
+			validationError(w, "latitude", "Latitude and longitude must be decimal numbers")
+			return area, false
+		}
+		area.latitude, area.longitude, area.byCoords = lat, lng, true
+	}
+
+	if v := q.Get("radius"); v != "" {
+		radius, err := strconv.Atoi(v)
+		if err != nil || radius < 0 || radius > BUSINESS_SEARCH_DEFAULT_RADIUS {
+			validationError(w, "radius", "radius must be an integer between 0 and 40000")
+			return area, false
+		}
+		area.radius = float64(radius)
+	}
+
+	return area, true
+}
+
+// eventSearch serves the Event Search API. It filters on location or coordinates, categories, is_free, start and end dates
+// and excluded events, then sorts on popularity (attending count) or start time and paginates.
+func (s *Server) eventSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	area, ok := parseEventArea(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset, ok := parseLimit(w, q, EVENT_SEARCH_DEFAULT_LIMIT, yelp.EVENT_SEARCH_MAX_LIMIT, 0)
+	if !ok {
+		return
+	}
+
+	var startDate, endDate int64
+	for _, param := range []struct {
+		name  string
+		value *int64
+	}{{"start_date", &startDate}, {"end_date", &endDate}} {
+		if v := q.Get(param.name); v != "" {
+			ts, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				validationError(w, param.name, param.name+" must be a Unix timestamp")
+				return
+			}
+			*param.value = ts
+		}
+	}
+
+	categories := splitList(q.Get("categories"))
+	excluded := splitList(q.Get("excluded_events"))
+
+	matches := []yelp.Event{}
+	for _, e := range s.events {
+		if !eventInArea(e, area) || (len(categories) > 0 && !containsString(categories, e.Category)) || containsString(excluded, e.ID) {
+			continue
+		}
+
+		if v := q.Get("is_free"); v != "" && strconv.FormatBool(e.IsFree) != v {
+			continue
+		}
+
+		if (startDate != 0 && eventStart(e).Unix() < startDate) || (endDate != 0 && eventEnd(e).Unix() > endDate) {
+			continue
+		}
+
+		matches = append(matches, e)
+	}
+
+	ascending := q.Get("sort_by") == yelp.EVENT_SORT_BY_ASC
+	less := func(i, j int) bool { return matches[i].AttendingCount < matches[j].AttendingCount }
+	if q.Get("sort_on") == yelp.EVENT_SORT_ON_TIME_START {
+		less = func(i, j int) bool { return eventStart(matches[i]).Before(eventStart(matches[j])) }
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if ascending {
+			return less(i, j)
+		}
+		return less(j, i)
+	})
+
+	writeJSON(w, yelp.EventSearchRes{Total: len(matches), Events: page(matches, offset, limit)})
+}
+
+// containsString reports whether values holds v.
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eventLookup serves the Event Lookup API.
+func (s *Server) eventLookup(w http.ResponseWriter, id string) {
+	for _, e := range s.events {
+		if e.ID == id {
+			writeJSON(w, yelp.EventDetailsRes{Event: e})
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "Resource could not be found.")
+}
+
+// featuredEvent serves the Featured Event API, returning the event set with SetFeaturedEvent,
+// or else the first event in the requested area.
+func (s *Server) featuredEvent(w http.ResponseWriter, r *http.Request) {
+	area, ok := parseEventArea(w, r)
+	if !ok {
+		return
+	}
+
+	if area.location == "" && !area.byCoords {
+		validationError(w, "location", "Please specify a location or a latitude and longitude")
+		return
+	}
+
+	for _, e := range s.events {
+		if (s.featuredEventID == "" || e.ID == s.featuredEventID) && eventInArea(e, area) {
+			writeJSON(w, yelp.EventDetailsRes{Event: e})
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "Resource could not be found.")
+}
@@ -0,0 +1,242 @@
+package yelptest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder sends requests to Yelp or serves them from fixtures.
+type RecorderMode int
+
+const (
+	MODE_REPLAY           RecorderMode = iota // Serve every request from its fixture, failing requests without one
+	MODE_RECORD                               // Send every request and save its response as a fixture, overwriting existing ones
+	MODE_REPLAY_OR_RECORD                     // Serve requests from their fixture when there is one, recording the others
+)
+
+// ErrNoFixture is returned in replay mode for requests without a recorded fixture
+var ErrNoFixture = errors.New("yelptest: no fixture recorded for request")
+
+// REDACTED replaces the API key in recorded fixtures
+const REDACTED = "REDACTED"
+
+// Recorder is an http.RoundTripper recording Yelp responses to a fixture directory and replaying them, to use as the
+// transport of ClientOptions.HTTPClient. Requests are matched on method, path and normalized query params,
+// and the API key is scrubbed from fixtures, which are never given request headers.
+//
+//	recorder := yelptest.NewRecorder("testdata/fixtures", yelptest.MODE_REPLAY)
+//	client, err := yelp.Init(&yelp.ClientOptions{APIKey: apiKey, HTTPClient: recorder.HTTPClient()})
+type Recorder struct {
+	Dir       string            // Directory fixtures are stored in
+	Mode      RecorderMode      // Whether requests are recorded or replayed
+	Transport http.RoundTripper // Optional. Transport sending recorded requests. Defaults to http.DefaultTransport
+
+	mu sync.Mutex
+}
+
+// fixture is a recorded request and response pair, stored as JSON.
+type fixture struct {
+	Request struct {
+		Method string     `json:"method"`
+		Path   string     `json:"path"`
+		Query  url.Values `json:"query"`
+	} `json:"request"`
+	Response struct {
+		StatusCode int             `json:"status_code"`
+		Header     http.Header     `json:"header"`
+		Body       json.RawMessage `json:"body"`
+	} `json:"response"`
+}
+
+// NewRecorder creates a recorder storing its fixtures in dir.
+func NewRecorder(dir string, mode RecorderMode) *Recorder {
+	return &Recorder{Dir: dir, Mode: mode}
+}
+
+// HTTPClient returns an http.Client using the recorder as its transport.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// normalizeQuery drops empty params and sorts the values of every param, so equivalent requests share a fixture.
+func normalizeQuery(q url.Values) url.Values {
+	normalized := make(url.Values)
+
+	for key, values := range q {
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				normalized[key] = append(normalized[key], v)
+			}
+		}
+		sort.Strings(normalized[key])
+	}
+
+	return normalized
+}
+
+// unsafeFileChars matches the characters replaced in fixture file names.
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// fixturePath returns the file the fixture of a request is stored in, named after its path and a hash of its method, path and query.
+func (r *Recorder) fixturePath(method string, path string, query url.Values) string {
+	sum := sha256.Sum256([]byte(method + " " + path + "?" + query.Encode()))
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(path, "_"), "_")
+
+	return filepath.Join(r.Dir, fmt.Sprintf("%s_%s_%s.json", strings.ToLower(method), name, hex.EncodeToString(sum[:6])))
+}
+
+// RoundTrip serves the request from its fixture or sends it and records the response, depending on the mode.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := normalizeQuery(req.URL.Query())
+	apiKey := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	query = scrubQuery(query, apiKey)
+
+	path := r.fixturePath(req.Method, req.URL.Path, query)
+
+	if r.Mode != MODE_RECORD {
+		data, err := ioutil.ReadFile(path)
+		if err == nil {
+			return replay(req, data)
+		}
+
+		if r.Mode == MODE_REPLAY {
+			return nil, fmt.Errorf("%w: %s %s?%s (looked for %s)", ErrNoFixture, req.Method, req.URL.Path, query.Encode(), path)
+		}
+	}
+
+	return r.record(req, path, query, apiKey)
+}
+
+// replay builds the response stored in a fixture.
+func replay(req *http.Request, data []byte) (*http.Response, error) {
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("yelptest: invalid fixture for %s %s: %v", req.Method, req.URL.Path, err)
+	}
+
+	body := []byte(f.Response.Body)
+	var text string
+	if err := json.Unmarshal(body, &text); err == nil {
+		body = []byte(text)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Response.StatusCode, http.StatusText(f.Response.StatusCode)),
+		StatusCode:    f.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        f.Response.Header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// record sends the request and saves its response to path with the API key scrubbed.
+func (r *Recorder) record(req *http.Request, path string, query url.Values, apiKey string) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var f fixture
+	f.Request.Method = req.Method
+	f.Request.Path = req.URL.Path
+	f.Request.Query = query
+	f.Response.StatusCode = res.StatusCode
+	f.Response.Header = scrubHeader(res.Header, apiKey)
+	f.Response.Body = scrubBody(body, apiKey)
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = os.MkdirAll(r.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	if err = ioutil.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// scrub replaces the API key in s.
+func scrub(s string, apiKey string) string {
+	if apiKey == "" {
+		return s
+	}
+
+	return strings.ReplaceAll(s, apiKey, REDACTED)
+}
+
+// scrubQuery replaces the API key in query values.
+func scrubQuery(q url.Values, apiKey string) url.Values {
+	for _, values := range q {
+		for i, v := range values {
+			values[i] = scrub(v, apiKey)
+		}
+	}
+
+	return q
+}
+
+// scrubHeader copies the response header without cookies and with the API key replaced.
+func scrubHeader(h http.Header, apiKey string) http.Header {
+	scrubbed := make(http.Header)
+
+	for key, values := range h {
+		if key == "Set-Cookie" || key == "Authorization" {
+			continue
+		}
+
+		for _, v := range values {
+			scrubbed.Add(key, scrub(v, apiKey))
+		}
+	}
+
+	return scrubbed
+}
+
+// scrubBody returns the body with the API key replaced, kept as is when it is JSON and as a JSON string otherwise.
+func scrubBody(body []byte, apiKey string) json.RawMessage {
+	scrubbed := []byte(scrub(string(body), apiKey))
+
+	if json.Valid(scrubbed) {
+		return scrubbed
+	}
+
+	encoded, _ := json.Marshal(string(scrubbed))
+	return encoded
+}
@@ -0,0 +1,98 @@
+package yelptest_test
+
+import (
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/yelptest"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func recordedClient(t *testing.T, baseURI string, recorder *yelptest.Recorder) *yelp.Client {
+	client, err := yelp.Init(&yelp.ClientOptions{APIKey: yelptest.API_KEY, HTTPClient: recorder.HTTPClient()})
+	assert.NoError(t, err)
+	client.BaseURI = baseURI
+
+	return client
+}
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	server := seededServer(t)
+	baseURI := server.URL
+
+	recording := recordedClient(t, baseURI, yelptest.NewRecorder(dir, yelptest.MODE_RECORD))
+	recorded, recordErr := recording.BusinessSearch(yelp.BusinessSearchReq{Location: "San Francisco", Categories: yelp.Categories{"bakeries"}})
+	_, recordMissingErr := recording.BusinessDetails("missing", "")
+
+	server.Close()
+
+	replaying := recordedClient(t, baseURI, yelptest.NewRecorder(dir, yelptest.MODE_REPLAY))
+
+	// Act
+	replayed, replayErr := replaying.BusinessSearch(yelp.BusinessSearchReq{Location: "San Francisco", Categories: yelp.Categories{"bakeries", "bakeries"}})
+	_, replayMissingErr := replaying.BusinessDetails("missing", "")
+
+	// Assert
+	assert.NoError(t, recordErr)
+	assert.NoError(t, replayErr)
+	assert.Equal(t, recorded, replayed)
+	assert.Equal(t, "tartine", replayed.Businesses[0].ID)
+	assert.Equal(t, 4999, replayed.RateLimit.Remaining)
+
+	assert.ErrorIs(t, recordMissingErr, yelp.ErrBusinessNotFound)
+	assert.ErrorIs(t, replayMissingErr, yelp.ErrBusinessNotFound)
+}
+
+func TestRecorderScrubsAPIKey(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	server := seededServer(t)
+	client := recordedClient(t, server.URL, yelptest.NewRecorder(dir, yelptest.MODE_RECORD))
+
+	// Act
+	_, err := client.BusinessSearch(yelp.BusinessSearchReq{Location: "San Francisco", Term: yelptest.API_KEY})
+
+	// Assert
+	assert.NoError(t, err)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Len(t, files, 1)
+	assert.True(t, strings.HasPrefix(filepath.Base(files[0]), "get_businesses_search_"))
+
+	data, _ := ioutil.ReadFile(files[0])
+	assert.NotContains(t, string(data), yelptest.API_KEY)
+	assert.Contains(t, string(data), yelptest.REDACTED)
+}
+
+func TestRecorderFailsOnUnmatchedRequest(t *testing.T) {
+	// Arrange
+	client := recordedClient(t, yelp.BASE_URI, yelptest.NewRecorder(t.TempDir(), yelptest.MODE_REPLAY))
+
+	// Act
+	_, err := client.BusinessDetails("gary-danko", "en_US")
+
+	// Assert
+	assert.ErrorIs(t, err, yelptest.ErrNoFixture)
+	assert.Contains(t, err.Error(), "GET /v3/businesses/gary-danko?locale=en_US")
+}
+
+func TestRecorderReplayOrRecord(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	server := seededServer(t)
+	client := recordedClient(t, server.URL, yelptest.NewRecorder(dir, yelptest.MODE_REPLAY_OR_RECORD))
+
+	// Act
+	first, firstErr := client.BusinessDetails("gary-danko", "")
+	second, secondErr := client.BusinessDetails("gary-danko", "")
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, first, second)
+	assert.Len(t, server.Requests(), 1)
+}
@@ -0,0 +1,305 @@
+// Package yelptest provides a stateful fake of the Yelp Fusion API, so code using yelp.Client can be tested offline.
+//
+//	server := yelptest.NewServer()
+//	defer server.Close()
+//
+//	server.AddBusinesses(yelp.BusinessDetailsRes{ID: "gary-danko", Name: "Gary Danko", ...})
+//
+//	client := server.Client(nil)
+//	res, err := client.BusinessSearch(yelp.BusinessSearchReq{Location: "San Francisco"})
+package yelptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// API_KEY is the API key accepted by a Server unless another one is set
+const API_KEY = "yelptest-api-key"
+
+// DEFAULT_DAILY_LIMIT is the daily quota of a Server unless another one is set with SetDailyLimit
+const DEFAULT_DAILY_LIMIT = 5000
+
+// Server is a fake Yelp Fusion API serving the businesses, reviews, events and categories it was seeded with.
+// It checks the bearer token, enforces a daily quota reported in RateLimit headers, and answers with Yelp's error payloads.
+// Seeding methods may be called at any time and are safe for concurrent use.
+type Server struct {
+	URL    string // Base URI of the fake, to use as yelp.Client.BaseURI
+	APIKey string // API key accepted by the fake. Defaults to API_KEY
+
+	srv *httptest.Server
+
+	mu              sync.Mutex
+	businesses      []yelp.BusinessDetailsRes
+	reviews         map[string][]yelp.Review
+	events          []yelp.Event
+	featuredEventID string
+	categories      []yelp.CategoryInfo
+	dailyLimit      int
+	remaining       int
+	resetTime       time.Time
+	failures        []Failure
+	requests        []*http.Request
+}
+
+// Failure is an error response the Server answers the next matching request with, see FailNext.
+type Failure struct {
+	Path        string // Optional. Only fail requests to this path, for example "/businesses/search". Matches every path when empty
+	StatusCode  int    // HTTP status code of the response, for example 503
+	Code        string // Optional. Yelp error code of the response, for example "INTERNAL_ERROR". The body is empty when not set
+	Description string // Optional. Description of the error
+	RetryAfter  int    // Optional. Value of the Retry-After header in seconds
+}
+
+// NewServer starts a fake Yelp Fusion API with no data. Close must be called once done.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:  API_KEY,
+		reviews: make(map[string][]yelp.Review),
+	}
+	s.SetDailyLimit(DEFAULT_DAILY_LIMIT)
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client creates a yelp.Client sending its requests to the fake. Options may be nil, and their APIKey defaults to the server one.
+func (s *Server) Client(options *yelp.ClientOptions) *yelp.Client {
+	opts := yelp.ClientOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	if opts.APIKey == "" {
+		opts.APIKey = s.APIKey
+	}
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = s.srv.Client()
+	}
+
+	client, _ := yelp.Init(&opts)
+	client.BaseURI = s.URL
+
+	return client
+}
+
+// AddBusinesses seeds businesses. Search results are returned in the order businesses were added, unless sorted otherwise.
+func (s *Server) AddBusinesses(businesses ...yelp.BusinessDetailsRes) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.businesses = append(s.businesses, businesses...)
+}
+
+// AddReviews seeds reviews of the business with the given ID.
+func (s *Server) AddReviews(businessID string, reviews ...yelp.Review) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reviews[businessID] = append(s.reviews[businessID], reviews...)
+}
+
+// AddEvents seeds events.
+func (s *Server) AddEvents(events ...yelp.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, events...)
+}
+
+// SetFeaturedEvent sets the event returned by the Featured Event API. Defaults to the first event in the requested location.
+func (s *Server) SetFeaturedEvent(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.featuredEventID = id
+}
+
+// AddCategories seeds categories.
+func (s *Server) AddCategories(categories ...yelp.CategoryInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.categories = append(s.categories, categories...)
+}
+
+// SetDailyLimit resets the daily quota to limit requests. Once it is used up, requests fail with 429 ACCESS_LIMIT_REACHED.
+func (s *Server) SetDailyLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	s.dailyLimit = limit
+	s.remaining = limit
+	s.resetTime = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// FailNext makes the next requests matching each failure fail with it, in order. Failed requests still use up the quota.
+func (s *Server) FailNext(failures ...Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failures...)
+}
+
+// Requests returns the requests received so far, including failed ones.
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*http.Request(nil), s.requests...)
+}
+
+// serveHTTP authenticates the request, applies the quota and injected failures, and routes it to its endpoint.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r)
+
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		writeError(w, http.StatusBadRequest, "TOKEN_MISSING", "An access token must be supplied in order to use this endpoint.")
+		return
+	}
+
+	if auth != "Bearer "+s.APIKey {
+		writeError(w, http.StatusUnauthorized, "TOKEN_INVALID", "Invalid access token or authorization header.")
+		return
+	}
+
+	if s.remaining <= 0 {
+		s.writeRateLimit(w)
+		writeError(w, http.StatusTooManyRequests, "ACCESS_LIMIT_REACHED", "You've reached the access limit for this client. Please contact api@yelp.com for assistance")
+		return
+	}
+
+	s.remaining--
+	s.writeRateLimit(w)
+
+	if f, ok := s.nextFailure(r.URL.Path); ok {
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", fmt.Sprint(f.RetryAfter))
+		}
+
+		if f.Code == "" {
+			w.WriteHeader(f.StatusCode)
+			return
+		}
+
+		writeError(w, f.StatusCode, f.Code, f.Description)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "NOT_FOUND", "Resource could not be found.")
+		return
+	}
+
+	s.route(w, r)
+}
+
+// nextFailure pops the first injected failure matching the path.
+func (s *Server) nextFailure(path string) (Failure, bool) {
+	for i, f := range s.failures {
+		if f.Path == "" || f.Path == path {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			return f, true
+		}
+	}
+
+	return Failure{}, false
+}
+
+// writeRateLimit sets the RateLimit headers Yelp sends along with every response.
+func (s *Server) writeRateLimit(w http.ResponseWriter) {
+	w.Header().Set("RateLimit-DailyLimit", fmt.Sprint(s.dailyLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(s.remaining))
+	w.Header().Set("RateLimit-ResetTime", s.resetTime.Format(time.RFC3339))
+}
+
+// route dispatches the request to the handler of its endpoint.
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	switch {
+	case path == yelp.BUSINESS_ENDPOINT+yelp.BUSINESS_SEARCH_ENDPOINT:
+		s.businessSearch(w, r)
+	case path == yelp.BUSINESS_ENDPOINT+yelp.BUSINESS_SEARCH_PHONE_ENDPOINT:
+		s.phoneSearch(w, r)
+	case path == yelp.BUSINESS_ENDPOINT+yelp.BUSINESS_MATCH_ENDPOINT:
+		s.businessMatch(w, r)
+	case path == yelp.BUSINESS_TRANSACTION_SEARCH_ENDPOINT:
+		s.transactionSearch(w, r)
+	case path == yelp.BUSINESS_AUTOCOMPLETE_ENDPOINT:
+		s.autocomplete(w, r)
+	case len(segments) == 2 && segments[0] == "businesses":
+		s.businessDetails(w, segments[1])
+	case len(segments) == 3 && segments[0] == "businesses" && segments[2] == "reviews":
+		s.businessReviews(w, segments[1])
+	case path == yelp.EVENTS_ENDPOINT:
+		s.eventSearch(w, r)
+	case path == yelp.EVENTS_ENDPOINT+yelp.EVENTS_FEATURED_ENDPOINT:
+		s.featuredEvent(w, r)
+	case len(segments) == 2 && segments[0] == "events":
+		s.eventLookup(w, segments[1])
+	case path == yelp.CATEGORIES_ENDPOINT:
+		writeJSON(w, yelp.CategoriesRes{Categories: s.categories})
+	case len(segments) == 2 && segments[0] == "categories":
+		s.categoryDetails(w, segments[1])
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Resource could not be found.")
+	}
+}
+
+// categoryDetails serves the Category Details API.
+func (s *Server) categoryDetails(w http.ResponseWriter, alias string) {
+	for _, c := range s.categories {
+		if c.Alias == alias {
+			writeJSON(w, yelp.CategoryDetailsRes{Category: c})
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "CATEGORY_NOT_FOUND", "The requested category could not be found.")
+}
+
+// writeJSON writes a 200 response with the JSON encoding of v.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in Yelp's format, for example {"error": {"code": "...", "description": "..."}}.
+func writeError(w http.ResponseWriter, statusCode int, code string, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"code": code, "description": description},
+	})
+}
+
+// validationError writes a 400 VALIDATION_ERROR response about the field.
+func validationError(w http.ResponseWriter, field string, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"code": "VALIDATION_ERROR", "description": description, "field": field},
+	})
+}
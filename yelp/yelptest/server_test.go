@@ -0,0 +1,284 @@
+package yelptest_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/naguigui/yelp-fusion/yelp"
+	"github.com/naguigui/yelp-fusion/yelp/yelptest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func seededServer(t *testing.T) *yelptest.Server {
+	server := yelptest.NewServer()
+	t.Cleanup(server.Close)
+
+	sf := yelp.LocationBusinessDetails{Location: yelp.Location{City: "San Francisco", State: "CA", Country: "US", ZipCode: "94109"}}
+
+	server.AddBusinesses(
+		yelp.BusinessDetailsRes{
+			ID: "gary-danko", Alias: "gary-danko-san-francisco", Name: "Gary Danko", Rating: 4.5, ReviewCount: 4525, Price: "$$$$",
+			Phone: "+14157492060", Categories: []yelp.Category{{Alias: "newamerican", Title: "American (New)"}},
+			Location: sf, Coordinates: yelp.Coordinates{Latitude: 37.80587, Longitude: -122.42058},
+		},
+		yelp.BusinessDetailsRes{
+			ID: "tartine", Alias: "tartine-bakery-san-francisco", Name: "Tartine Bakery", Rating: 4, ReviewCount: 8000, Price: "$$",
+			Phone: "+14154872600", Categories: []yelp.Category{{Alias: "bakeries", Title: "Bakeries"}}, Transactions: []string{"delivery"},
+			Location: sf, Coordinates: yelp.Coordinates{Latitude: 37.76139, Longitude: -122.42415},
+		},
+		yelp.BusinessDetailsRes{
+			ID: "katz", Name: "Katz's Delicatessen", Rating: 4, ReviewCount: 14000, Price: "$$",
+			Phone: "+12122542246", Categories: []yelp.Category{{Alias: "delis", Title: "Delis"}},
+			Location:    yelp.LocationBusinessDetails{Location: yelp.Location{City: "New York", State: "NY", Country: "US"}},
+			Coordinates: yelp.Coordinates{Latitude: 40.72223, Longitude: -73.98741},
+		},
+	)
+
+	server.AddReviews("gary-danko",
+		yelp.Review{ID: "r1", Rating: 5}, yelp.Review{ID: "r2", Rating: 4}, yelp.Review{ID: "r3", Rating: 5}, yelp.Review{ID: "r4", Rating: 3},
+	)
+
+	return server
+}
+
+func TestBusinessSearchFiltersAndSorts(t *testing.T) {
+	// Arrange
+	server := seededServer(t)
+	client := server.Client(nil)
+
+	// Act
+	all, allErr := client.BusinessSearch(yelp.BusinessSearchReq{Location: "San Francisco, CA", SortBy: yelp.SORT_BY_REVIEW_COUNT})
+	cheap, cheapErr := client.BusinessSearch(yelp.BusinessSearchReq{Location: "San Francisco", Price: yelp.PriceLevels{yelp.PRICE_LEVEL_2}})
+	nearby, nearbyErr := client.BusinessSearch(yelp.BusinessSearchReq{Latitude: 37.8, Longitude: -122.42, Radius: 2000, Term: "american"})
+
+	// Assert
+	assert.NoError(t, allErr)
+	assert.Equal(t, 2, all.Total)
+	assert.Equal(t, "tartine", all.Businesses[0].ID)
+	assert.Equal(t, "gary-danko", all.Businesses[1].ID)
+
+	assert.NoError(t, cheapErr)
+	assert.Equal(t, 1, cheap.Total)
+	assert.Equal(t, "tartine", cheap.Businesses[0].ID)
+
+	assert.NoError(t, nearbyErr)
+	assert.Equal(t, 1, nearby.Total)
+	assert.Equal(t, "gary-danko", nearby.Businesses[0].ID)
+	assert.Greater(t, nearby.Businesses[0].Distance, float32(0))
+}
+
+func TestBusinessSearchPagination(t *testing.T) {
+	// Arrange
+	server := yelptest.NewServer()
+	defer server.Close()
+
+	for i := 0; i < 120; i++ {
+		server.AddBusinesses(yelp.BusinessDetailsRes{
+			ID:       fmt.Sprintf("business-%d", i),
+			Location: yelp.LocationBusinessDetails{Location: yelp.Location{City: "Toronto"}},
+		})
+	}
+
+	client := server.Client(nil)
+	it := client.NewBusinessSearchIterator(context.Background(), yelp.BusinessSearchReq{Location: "Toronto"}, nil)
+
+	// Act
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Business().ID)
+	}
+
+	// Assert
+	assert.NoError(t, it.Err())
+	assert.Len(t, ids, 120)
+	assert.Equal(t, "business-119", ids[119])
+	assert.Len(t, server.Requests(), 3)
+}
+
+func TestBusinessSearchUnknownLocation(t *testing.T) {
+	// Arrange
+	server := seededServer(t)
+	client := server.Client(nil)
+
+	// Act
+	_, err := client.BusinessSearch(yelp.BusinessSearchReq{Location: "Atlantis"})
+
+	// Assert
+	assert.EqualError(t, err, "400 Bad Request: LOCATION_NOT_FOUND: Could not execute search, try specifying a more exact location.")
+}
+
+func TestBusinessDetailsReviewsAndPhoneSearch(t *testing.T) {
+	// Arrange
+	server := seededServer(t)
+	client := server.Client(nil)
+
+	// Act
+	details, detailsErr := client.BusinessDetails("gary-danko-san-francisco", "")
+	_, missingErr := client.BusinessDetails("missing", "")
+	reviews, reviewsErr := client.BusinessReviews("gary-danko", "")
+	phone, phoneErr := client.BusinessPhoneSearch("+12122542246", "")
+
+	// Assert
+	assert.NoError(t, detailsErr)
+	assert.Equal(t, "Gary Danko", details.Name)
+
+	assert.ErrorIs(t, missingErr, yelp.ErrBusinessNotFound)
+
+	assert.NoError(t, reviewsErr)
+	assert.Equal(t, 4, reviews.Total)
+	assert.Len(t, reviews.Reviews, 3)
+
+	assert.NoError(t, phoneErr)
+	assert.Equal(t, 1, phone.Total)
+	assert.Equal(t, "katz", phone.Businesses[0].ID)
+}
+
+func TestAutocompleteAndTransactionSearch(t *testing.T) {
+	// Arrange
+	server := seededServer(t)
+	client := server.Client(nil)
+
+	// Act
+	suggestions, suggestionsErr := client.Autocomplete(yelp.BusinessAutocompleteReq{Text: "bak", Coordinates: yelp.Coordinates{Latitude: 37.78, Longitude: -122.4}})
+	delivery, deliveryErr := client.TransactionSearch(yelp.BusinessTransactionReq{Location: "San Francisco"})
+
+	// Assert
+	assert.NoError(t, suggestionsErr)
+	assert.Equal(t, []yelp.AutocompleteBusiness{{Name: "Tartine Bakery", ID: "tartine"}}, suggestions.Businesses)
+	assert.Equal(t, []yelp.Category{{Alias: "bakeries", Title: "Bakeries"}}, suggestions.Categories)
+	assert.Equal(t, []yelp.Term{{Text: "Bakeries"}, {Text: "Tartine Bakery"}}, suggestions.Terms)
+
+	assert.NoError(t, deliveryErr)
+	assert.Equal(t, 1, delivery.Total)
+	assert.Equal(t, "tartine", delivery.Businesses[0].ID)
+}
+
+func TestBusinessMatch(t *testing.T) {
+	// Arrange
+	server := seededServer(t)
+	client := server.Client(nil)
+
+	req := yelp.BusinessMatchReq{Name: "gary danko", Address1: "800 N Point St", City: "San Francisco", State: "CA", Country: "US"}
+	samePostalCode, otherPostalCode := req, req
+	samePostalCode.PostalCode = "94109"
+	otherPostalCode.PostalCode = "94110"
+
+	// Act
+	res, err := client.BusinessMatch(req)
+	same, sameErr := client.BusinessMatch(samePostalCode)
+	other, otherErr := client.BusinessMatch(otherPostalCode)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.Businesses, 1)
+	assert.Equal(t, "gary-danko", res.Businesses[0].ID)
+	assert.NoError(t, sameErr)
+	assert.Len(t, same.Businesses, 1)
+	assert.NoError(t, otherErr)
+	assert.Empty(t, other.Businesses)
+	assert.Equal(t, "94110", server.Requests()[2].URL.Query().Get("postal_code"))
+}
+
+func TestEventsAndCategories(t *testing.T) {
+	// Arrange
+	server := yelptest.NewServer()
+	defer server.Close()
+
+	toronto := yelp.EventLocation{Location: yelp.Location{City: "Toronto"}}
+	server.AddEvents(
+		yelp.Event{ID: "jazz", Category: "music", AttendingCount: 10, IsFree: true, TimeStart: "2026-07-01T20:00:00-04:00", Location: toronto},
+		yelp.Event{ID: "film", Category: "film", AttendingCount: 50, TimeStart: "2026-06-01T20:00:00-04:00", Location: toronto},
+		yelp.Event{ID: "opera", Category: "music", AttendingCount: 30, TimeStart: "2026-08-01T20:00:00-04:00", Location: toronto},
+	)
+	server.SetFeaturedEvent("opera")
+	server.AddCategories(
+		yelp.CategoryInfo{Alias: "restaurants", Title: "Restaurants"},
+		yelp.CategoryInfo{Alias: "bakeries", Title: "Bakeries", ParentAliases: []string{"food"}},
+	)
+
+	client := server.Client(nil)
+
+	// Act
+	popular, popularErr := client.EventSearch(yelp.EventSearchReq{Location: "Toronto", Categories: "music"})
+	upcoming, upcomingErr := client.EventSearch(yelp.EventSearchReq{Location: "Toronto", SortOn: yelp.EVENT_SORT_ON_TIME_START, SortBy: yelp.EVENT_SORT_BY_ASC})
+	featured, featuredErr := client.FeaturedEvent(yelp.FeaturedEventReq{Location: "Toronto"})
+	_, lookupErr := client.EventLookup("missing", "")
+	categories, categoriesErr := client.Categories("")
+	category, categoryErr := client.CategoryDetails("bakeries", "")
+
+	// Assert
+	assert.NoError(t, popularErr)
+	assert.Equal(t, 2, popular.Total)
+	assert.Equal(t, "opera", popular.Events[0].ID)
+
+	assert.NoError(t, upcomingErr)
+	assert.Equal(t, []string{"film", "jazz", "opera"}, []string{upcoming.Events[0].ID, upcoming.Events[1].ID, upcoming.Events[2].ID})
+
+	assert.NoError(t, featuredErr)
+	assert.Equal(t, "opera", featured.ID)
+
+	assert.EqualError(t, lookupErr, "404 Not Found: NOT_FOUND: Resource could not be found.")
+
+	assert.NoError(t, categoriesErr)
+	assert.Len(t, categories.Categories, 2)
+
+	assert.NoError(t, categoryErr)
+	assert.Equal(t, []string{"food"}, category.Category.ParentAliases)
+}
+
+func TestAuthentication(t *testing.T) {
+	// Arrange
+	server := seededServer(t)
+	client := server.Client(&yelp.ClientOptions{APIKey: "wrong-key"})
+
+	// Act
+	_, err := client.BusinessDetails("gary-danko", "")
+
+	// Assert
+	assert.ErrorIs(t, err, yelp.ErrTokenInvalid)
+}
+
+func TestDailyLimit(t *testing.T) {
+	// Arrange
+	server := seededServer(t)
+	server.SetDailyLimit(2)
+	client := server.Client(nil)
+
+	// Act
+	first, firstErr := client.BusinessDetails("gary-danko", "")
+	_, secondErr := client.BusinessDetails("gary-danko", "")
+	_, thirdErr := client.BusinessDetails("gary-danko", "")
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.Equal(t, 2, first.RateLimit.DailyLimit)
+	assert.Equal(t, 1, first.RateLimit.Remaining)
+	assert.NoError(t, secondErr)
+	assert.ErrorIs(t, thirdErr, yelp.ErrTooManyRequests)
+
+	info, ok := client.LastRateLimit()
+	assert.True(t, ok)
+	assert.Equal(t, 0, info.Remaining)
+}
+
+func TestFailNextIsRetried(t *testing.T) {
+	// Arrange
+	server := seededServer(t)
+	server.FailNext(
+		yelptest.Failure{Path: "/businesses/gary-danko", StatusCode: 503},
+		yelptest.Failure{StatusCode: 429, Code: "TOO_MANY_REQUESTS_PER_SECOND", Description: "You have exceeded the queries-per-second limit for this endpoint."},
+	)
+
+	client := server.Client(&yelp.ClientOptions{
+		RetryPolicy: &yelp.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond},
+	})
+
+	// Act
+	res, err := client.BusinessDetails("gary-danko", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Gary Danko", res.Name)
+	assert.Len(t, server.Requests(), 3)
+}